@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jeonghoikun/jinwoowide.com/store"
+	"github.com/jeonghoikun/jinwoowide.com/user"
+)
+
+const usage = `사용법:
+  jinwoowide                                 서버 실행
+  jinwoowide user add <name> <editor|admin>  관리자 계정 추가. 비밀번호는 표준입력으로
+  jinwoowide user passwd <name>              비밀번호 변경. 비밀번호는 표준입력으로
+  jinwoowide user list                       계정 목록
+  jinwoowide report [-json] [-all]           업소 콘텐츠 점검. 빠진 콘텐츠가 있으면 exit code 1
+  jinwoowide duplicates [-json] [-min-words N] [-similarity 0~1]
+                                             짧은 소개글, 비슷한 소개글 찾기. 있으면 exit code 1
+  jinwoowide migrate-geo [-dry-run]          data 파일의 googleMapSrc 좌표를 geo로 옮김`
+
+// readPassword: 표준입력의 첫 줄
+func readPassword() (string, error) {
+	fmt.Fprint(os.Stderr, "비밀번호: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// command: 서버 대신 관리 명령을 실행하고 exit code를 반환
+func command(args []string) int {
+	switch args[0] {
+	case "user":
+		return userCommand(args[1:])
+	case "report":
+		return reportCommand(args[1:])
+	case "duplicates":
+		return duplicatesCommand(args[1:])
+	case "migrate-geo":
+		return migrateGeoCommand(args[1:])
+	}
+	fmt.Fprintln(os.Stderr, usage)
+	return 2
+}
+
+func userCommand(sub []string) int {
+	if len(sub) == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+	var err error
+	switch {
+	case sub[0] == "add" && len(sub) == 3:
+		var role user.Role
+		if err = user.ValidateName(sub[1]); err != nil {
+			break
+		}
+		if role, err = user.ParseRole(sub[2]); err != nil {
+			break
+		}
+		var password string
+		if password, err = readPassword(); err != nil {
+			break
+		}
+		err = user.Add(sub[1], password, role)
+	case sub[0] == "passwd" && len(sub) == 2:
+		if _, has := user.Get(sub[1]); !has {
+			err = fmt.Errorf("name: %q 없는 계정입니다", sub[1])
+			break
+		}
+		var password string
+		if password, err = readPassword(); err != nil {
+			break
+		}
+		err = user.SetPassword(sub[1], password)
+	case sub[0] == "list" && len(sub) == 1:
+		for _, u := range user.List() {
+			fmt.Printf("%s\t%s\t%s\n", u.Name, u.Role, u.DateCreated.Format("2006-01-02"))
+		}
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// reportCommand: 업소 콘텐츠 점검 결과 출력. 기본은 빠진 콘텐츠가 있는 업소만
+func reportCommand(args []string) int {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "JSON으로 출력")
+	all := fs.Bool("all", false, "빠진 콘텐츠가 없는 업소도 출력")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	reports := store.Current().Reports(false)
+	incomplete := 0
+	list := reports[:0:0]
+	for _, r := range reports {
+		if !r.Complete() {
+			incomplete++
+		}
+		if *all || !r.Complete() {
+			list = append(list, r)
+		}
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "\t")
+		if err := enc.Encode(list); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	} else {
+		for _, r := range list {
+			fmt.Printf("%s (%s)\n", r.File, r.Path)
+			if r.Complete() {
+				fmt.Println("  완료")
+			}
+			for _, issue := range r.Issues {
+				fmt.Printf("  - %s: %s\n", issue.Field, issue.Message)
+			}
+		}
+	}
+	fmt.Fprintf(os.Stderr, "업소 %d개 중 %d개에 빠진 콘텐츠가 있습니다\n", len(reports), incomplete)
+	if incomplete > 0 {
+		return 1
+	}
+	return 0
+}
+
+// duplicatesCommand: 단어 수가 적은 소개글과 서로 비슷한 소개글 쌍 출력
+func duplicatesCommand(args []string) int {
+	fs := flag.NewFlagSet("duplicates", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "JSON으로 출력")
+	minWords := fs.Int("min-words", 150, "이보다 단어(어절) 수가 적으면 짧은 소개글")
+	similarity := fs.Float64("similarity", 0.3, "유사도(0~1)가 이 이상이면 비슷한 소개글")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *similarity < 0 || *similarity > 1 {
+		fmt.Fprintln(os.Stderr, "similarity: 0 ~ 1 사이여야 합니다")
+		return 2
+	}
+	r := store.Current().Duplicates(*minWords, *similarity)
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "\t")
+		if err := enc.Encode(r); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	} else {
+		fmt.Printf("짧은 소개글 (%d단어 미만)\n", r.MinWords)
+		for _, a := range r.Thin {
+			fmt.Printf("  %4d  %s (%s)\n", a.Words, a.File, a.Path)
+		}
+		fmt.Printf("비슷한 소개글 (유사도 %.2f 이상)\n", r.MinSimilarity)
+		for _, p := range r.Similar {
+			fmt.Printf("  %.2f  %s\n        %s\n", p.Similarity, p.A.File, p.B.File)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "소개글 %d개 중 짧은 소개글 %d개, 비슷한 소개글 %d쌍\n", r.Articles, len(r.Thin), len(r.Similar))
+	if len(r.Thin) > 0 || len(r.Similar) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// migrateGeoCommand: data 파일의 googleMapSrc 좌표를 geo로 옮김
+func migrateGeoCommand(args []string) int {
+	fs := flag.NewFlagSet("migrate-geo", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "파일을 바꾸지 않고 옮길 파일만 출력")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	m, err := store.MigrateGeo(*dryRun)
+	for _, path := range m.Migrated {
+		fmt.Println(path)
+	}
+	for _, path := range m.Failed {
+		fmt.Fprintf(os.Stderr, "%s: googleMapSrc에서 좌표를 찾을 수 없습니다\n", path)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Fprintf(os.Stderr, "%d개 파일을 옮겼습니다, %d개 파일은 옮기지 못했습니다\n", len(m.Migrated), len(m.Failed))
+	if len(m.Failed) > 0 {
+		return 1
+	}
+	return 0
+}
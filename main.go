@@ -2,11 +2,14 @@ package main
 
 import (
 	"log"
+	"os"
 	"time"
 
+	"github.com/jeonghoikun/jinwoowide.com/media"
 	"github.com/jeonghoikun/jinwoowide.com/server"
 	"github.com/jeonghoikun/jinwoowide.com/site"
 	"github.com/jeonghoikun/jinwoowide.com/store"
+	"github.com/jeonghoikun/jinwoowide.com/user"
 )
 
 func init() {
@@ -19,9 +22,17 @@ func init() {
 	if err := store.Init(); err != nil {
 		panic(err)
 	}
+	if err := user.Init(); err != nil {
+		panic(err)
+	}
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		os.Exit(command(os.Args[1:]))
+	}
+	media.CheckEncoders()
+	go store.Watch(3 * time.Second)
 	s := server.New(site.Config.Port)
 	log.Fatal(s.Run())
 }
@@ -0,0 +1,109 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+)
+
+// jpegOrientation: JPEG의 EXIF Orientation 값(1~8). 없거나 읽을 수 없으면 1.
+// 휴대폰 사진은 가로로 저장하고 Orientation으로 회전을 표시하는 경우가 많아서,
+// EXIF를 지우기 전에 회전을 픽셀에 적용해야 함
+func jpegOrientation(b []byte) int {
+	if len(b) < 4 || b[0] != 0xFF || b[1] != 0xD8 {
+		return 1
+	}
+	for i := 2; i+4 <= len(b); {
+		if b[i] != 0xFF {
+			return 1
+		}
+		marker := b[i+1]
+		// SOS 이후는 이미지 데이터
+		if marker == 0xDA {
+			return 1
+		}
+		size := int(binary.BigEndian.Uint16(b[i+2 : i+4]))
+		if size < 2 || i+2+size > len(b) {
+			return 1
+		}
+		seg := b[i+4 : i+2+size]
+		if marker == 0xE1 && bytes.HasPrefix(seg, []byte("Exif\x00\x00")) {
+			return tiffOrientation(seg[6:])
+		}
+		i += 2 + size
+	}
+	return 1
+}
+
+func tiffOrientation(t []byte) int {
+	if len(t) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(t[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	ifd := int(order.Uint32(t[4:8]))
+	if ifd+2 > len(t) {
+		return 1
+	}
+	n := int(order.Uint16(t[ifd : ifd+2]))
+	for i := 0; i < n; i++ {
+		e := ifd + 2 + i*12
+		if e+12 > len(t) {
+			return 1
+		}
+		if order.Uint16(t[e:e+2]) != 0x0112 {
+			continue
+		}
+		if o := int(order.Uint16(t[e+8 : e+10])); o >= 1 && o <= 8 {
+			return o
+		}
+		return 1
+	}
+	return 1
+}
+
+// orient: EXIF Orientation을 적용한 이미지. 1이면 그대로
+func orient(src *image.NRGBA, o int) *image.NRGBA {
+	if o <= 1 || o > 8 {
+		return src
+	}
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	dw, dh := w, h
+	// 5~8은 가로, 세로가 바뀜
+	if o >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch o {
+			case 2:
+				dx, dy = w-1-x, y
+			case 3:
+				dx, dy = w-1-x, h-1-y
+			case 4:
+				dx, dy = x, h-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = h-1-y, x
+			case 7:
+				dx, dy = h-1-y, w-1-x
+			case 8:
+				dx, dy = y, w-1-x
+			}
+			si := src.PixOffset(src.Rect.Min.X+x, src.Rect.Min.Y+y)
+			di := dst.PixOffset(dx, dy)
+			copy(dst.Pix[di:di+4], src.Pix[si:si+4])
+		}
+	}
+	return dst
+}
@@ -0,0 +1,347 @@
+package media
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	// 업로드 가능한 이미지 포맷. jpeg, png, gif는 표준 라이브러리
+	_ "golang.org/x/image/bmp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/tiff"
+	_ "golang.org/x/image/webp"
+)
+
+// MAX_UPLOAD_SIZE: 업로드 파일 한개의 최대 크기
+const MAX_UPLOAD_SIZE = 20 << 20
+
+// maxPixels: 디코딩하기 전에 크기를 확인해서 너무 큰 이미지(압축 폭탄 등)는 거부
+const maxPixels = 50_000_000
+
+// minSide: 너무 작은 이미지는 갤러리에 쓸 수 없음
+const minSide = 200
+
+// WIDTHS: 저장할 너비. 원본보다 큰 너비는 만들지 않고, 원본이 가장 큰 너비보다 작으면 원본 너비를 추가
+var WIDTHS = []int{480, 960, 1440, 1920}
+
+const (
+	FORMAT_JPEG = "jpg"
+	FORMAT_PNG  = "png"
+	FORMAT_WEBP = "webp"
+	FORMAT_AVIF = "avif"
+)
+
+// encoder: WebP, AVIF 인코더. Go 표준 라이브러리에 인코더가 없어서 PATH에 있는 명령어를 사용하고, 없으면 만들지 않음
+type encoder struct {
+	format string
+	bin    string
+	args   func(in, out string) []string
+}
+
+var encoders = []*encoder{
+	{FORMAT_AVIF, "avifenc", func(in, out string) []string {
+		return []string{"-q", "60", "-s", "6", in, out}
+	}},
+	{FORMAT_WEBP, "cwebp", func(in, out string) []string {
+		return []string{"-quiet", "-q", "80", "-metadata", "none", in, "-o", out}
+	}},
+}
+
+// Image: 업로드 처리된 이미지 한장. 파일은 {Name}-{너비}.{포맷}
+type Image struct {
+	// Name: 원본 내용의 해시. 같은 이미지를 다시 올리면 같은 이름
+	Name string `json:"name"`
+	// Ext: 기본 포맷. 투명한 부분이 있으면 png, 아니면 jpg
+	Ext    string `json:"ext"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	// Widths: 저장된 너비. 작은 순서
+	Widths []int `json:"widths"`
+	// Formats: Ext 외에 함께 저장된 포맷. ex) avif, webp
+	Formats []string `json:"formats,omitempty"`
+	// Alt: 이미지 설명. 없으면 업소 이름 등으로 자동 생성
+	Alt string `json:"alt,omitempty"`
+}
+
+// File: 너비 w, 포맷 format의 파일 이름
+func (img *Image) File(w int, format string) string {
+	return fmt.Sprintf("%s-%d.%s", img.Name, w, format)
+}
+
+// Files: 저장된 모든 파일 이름
+func (img *Image) Files() []string {
+	var files []string
+	for _, format := range append([]string{img.Ext}, img.Formats...) {
+		for _, w := range img.Widths {
+			files = append(files, img.File(w, format))
+		}
+	}
+	return files
+}
+
+// Src: 가장 큰 너비의 기본 포맷 URL. dir은 이미지 디렉토리 URL
+func (img *Image) Src(dir string) string {
+	return dir + "/" + img.File(img.Widths[len(img.Widths)-1], img.Ext)
+}
+
+// Srcset: ex) /static/.../abc-480.webp 480w, /static/.../abc-960.webp 960w
+func (img *Image) Srcset(dir, format string) string {
+	ss := make([]string, 0, len(img.Widths))
+	for _, w := range img.Widths {
+		ss = append(ss, fmt.Sprintf("%s/%s %dw", dir, img.File(w, format), w))
+	}
+	return strings.Join(ss, ", ")
+}
+
+// MimeType: <source type="">에 사용
+func MimeType(format string) string {
+	if format == FORMAT_JPEG {
+		return "image/jpeg"
+	}
+	return "image/" + format
+}
+
+// Source: <picture>의 <source> 한개
+type Source struct {
+	Type   string
+	Srcset string
+}
+
+// Sources: Formats의 <source> 목록. 브라우저는 지원하는 첫번째 포맷을 사용하므로 작은 포맷(avif)부터
+func (img *Image) Sources(dir string) []*Source {
+	list := make([]*Source, 0, len(img.Formats))
+	for _, format := range img.Formats {
+		list = append(list, &Source{Type: MimeType(format), Srcset: img.Srcset(dir, format)})
+	}
+	return list
+}
+
+// Validate: data 파일에 기록된 값 검사
+func (img *Image) Validate() error {
+	if img.Name == "" {
+		return fmt.Errorf("name: 값이 없습니다")
+	}
+	if img.Ext != FORMAT_JPEG && img.Ext != FORMAT_PNG {
+		return fmt.Errorf("ext: %q jpg 또는 png 이어야 합니다", img.Ext)
+	}
+	if len(img.Widths) == 0 {
+		return fmt.Errorf("widths: 값이 없습니다")
+	}
+	for _, f := range img.Formats {
+		if f != FORMAT_WEBP && f != FORMAT_AVIF {
+			return fmt.Errorf("formats: %q webp 또는 avif 이어야 합니다", f)
+		}
+	}
+	return nil
+}
+
+// widths: 원본 너비 w에서 만들 너비 목록
+func widths(w int) []int {
+	var list []int
+	for _, width := range WIDTHS {
+		if width >= w {
+			break
+		}
+		list = append(list, width)
+	}
+	if max := WIDTHS[len(WIDTHS)-1]; w < max {
+		return append(list, w)
+	}
+	return list
+}
+
+// decode: 업로드 이미지의 포맷과 크기를 확인한 뒤 디코딩
+func decode(b []byte) (*image.NRGBA, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("지원하지 않는 이미지 형식입니다 (jpeg, png, gif, webp, bmp, tiff)")
+	}
+	if cfg.Width < minSide || cfg.Height < minSide {
+		return nil, fmt.Errorf("이미지가 너무 작습니다 (%dx%d). 가로, 세로 %dpx 이상이어야 합니다", cfg.Width, cfg.Height, minSide)
+	}
+	return decodeImage(b)
+}
+
+// decodeImage: 크기를 확인한 뒤 디코딩하고 EXIF 회전을 적용
+func decodeImage(b []byte) (*image.NRGBA, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("지원하지 않는 이미지 형식입니다 (jpeg, png, gif, webp, bmp, tiff)")
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return nil, fmt.Errorf("이미지가 너무 큽니다 (%dx%d)", cfg.Width, cfg.Height)
+	}
+	src, _, err := image.Decode(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("이미지를 읽을 수 없습니다: %s", err)
+	}
+	m := image.NewNRGBA(image.Rect(0, 0, src.Bounds().Dx(), src.Bounds().Dy()))
+	draw.Draw(m, m.Rect, src, src.Bounds().Min, draw.Src)
+	if format == "jpeg" {
+		m = orient(m, jpegOrientation(b))
+	}
+	return m, nil
+}
+
+// resize: 너비 w로 비율을 유지해서 줄임. w가 원본 너비 이상이면 그대로
+func resize(m *image.NRGBA, w int) *image.NRGBA {
+	if w >= m.Rect.Dx() {
+		return m
+	}
+	h := (m.Rect.Dy()*w + m.Rect.Dx()/2) / m.Rect.Dx()
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Rect, m, m.Rect, draw.Src, nil)
+	return dst
+}
+
+func encode(path string, m image.Image, ext string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if ext == FORMAT_PNG {
+		err = (&png.Encoder{CompressionLevel: png.BestCompression}).Encode(f, m)
+	} else {
+		err = jpeg.Encode(f, m, &jpeg.Options{Quality: 82})
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Name: 이미지 내용 b로 만든 파일 이름
+func Name(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:6])
+}
+
+// Process: 업로드된 이미지 b를 검사하고 dir에 너비별로 저장. 메타데이터(EXIF, GPS 등)는 다시 인코딩하면서 모두 제거됨.
+// 에러가 나면 만든 파일은 지움
+func Process(b []byte, dir string) (img *Image, err error) {
+	if len(b) > MAX_UPLOAD_SIZE {
+		return nil, fmt.Errorf("파일이 너무 큽니다. %dMB 이하만 올릴 수 있습니다", MAX_UPLOAD_SIZE>>20)
+	}
+	m, err := decode(b)
+	if err != nil {
+		return nil, err
+	}
+	img = &Image{
+		Name:   Name(b),
+		Ext:    FORMAT_JPEG,
+		Width:  m.Rect.Dx(),
+		Height: m.Rect.Dy(),
+		Widths: widths(m.Rect.Dx()),
+	}
+	if !m.Opaque() {
+		img.Ext = FORMAT_PNG
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	var written []string
+	defer func() {
+		if err != nil {
+			for _, path := range written {
+				os.Remove(path)
+			}
+		}
+	}()
+	for _, w := range img.Widths {
+		path := filepath.Join(dir, img.File(w, img.Ext))
+		if err := encode(path, resize(m, w), img.Ext); err != nil {
+			return nil, err
+		}
+		written = append(written, path)
+	}
+	for _, e := range encoders {
+		files, err := e.run(dir, img)
+		if err != nil {
+			// 인코더가 실패해도 기본 포맷은 있으므로 해당 포맷만 빼고 진행
+			log.Printf("media: %s", err)
+			continue
+		}
+		if files != nil {
+			img.Formats = append(img.Formats, e.format)
+		}
+	}
+	return img, nil
+}
+
+// findEncoder: format의 인코더. 없거나 명령어가 PATH에 없으면 nil
+func findEncoder(format string) *encoder {
+	for _, e := range encoders {
+		if e.format != format {
+			continue
+		}
+		if _, err := exec.LookPath(e.bin); err != nil {
+			return nil
+		}
+		return e
+	}
+	return nil
+}
+
+// CheckEncoders: PATH에 없는 인코더를 로그로 남김. 서버 시작할때 한번 호출.
+// 없으면 해당 포맷 없이 jpg, png만 만들어지므로 배포 환경에서 알 수 있도록
+func CheckEncoders() {
+	for _, e := range encoders {
+		if _, err := exec.LookPath(e.bin); err != nil {
+			log.Printf("media: %s 명령어가 PATH에 없어서 %s 이미지를 만들지 않습니다", e.bin, e.format)
+		}
+	}
+}
+
+// CanEncode: format으로 저장할 수 있는지. jpg, png는 항상 가능
+func CanEncode(format string) bool {
+	if format == FORMAT_JPEG || format == FORMAT_PNG {
+		return true
+	}
+	return findEncoder(format) != nil
+}
+
+// convert: 파일 in을 e.format의 파일 out으로 변환
+func (e *encoder) convert(in, out string) error {
+	if b, err := exec.Command(e.bin, e.args(in, out)...).CombinedOutput(); err != nil {
+		os.Remove(out)
+		return fmt.Errorf("%s: %s: %s", e.bin, err, bytes.TrimSpace(b))
+	}
+	return nil
+}
+
+// run: 기본 포맷 파일들을 e.format으로 변환. 명령어가 없으면 nil, nil
+func (e *encoder) run(dir string, img *Image) ([]string, error) {
+	if _, err := exec.LookPath(e.bin); err != nil {
+		return nil, nil
+	}
+	var files []string
+	for _, w := range img.Widths {
+		in := filepath.Join(dir, img.File(w, img.Ext))
+		out := filepath.Join(dir, img.File(w, e.format))
+		if err := e.convert(in, out); err != nil {
+			for _, f := range files {
+				os.Remove(f)
+			}
+			return nil, err
+		}
+		files = append(files, out)
+	}
+	return files, nil
+}
+
+// Remove: img의 파일을 dir에서 지움. 없는 파일은 무시
+func Remove(dir string, img *Image) {
+	for _, f := range img.Files() {
+		os.Remove(filepath.Join(dir, f))
+	}
+}
@@ -0,0 +1,204 @@
+package media
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// CACHE_DIR: 요청받은 크기, 포맷으로 변환한 이미지 캐시. 지워도 다시 만들어짐
+const CACHE_DIR = "cache/img"
+
+// RESIZE_WIDTHS: /image 경로로 요청할 수 있는 너비. 아무 너비나 받으면 캐시가 끝없이 커지므로 정해진 너비만
+var RESIZE_WIDTHS = []int{320, 480, 640, 960, 1440, 1920}
+
+// IsResizeWidth: w가 RESIZE_WIDTHS에 있는지
+func IsResizeWidth(w int) bool {
+	for _, width := range RESIZE_WIDTHS {
+		if width == w {
+			return true
+		}
+	}
+	return false
+}
+
+// sourceHash: 원본 파일 내용의 해시. 요청마다 파일 전체를 읽지 않도록 수정시간, 크기가 같으면 이전 값을 사용
+type sourceHash struct {
+	modTime time.Time
+	size    int64
+	hash    string
+}
+
+var (
+	hashMu sync.Mutex
+	hashes = map[string]*sourceHash{}
+)
+
+func hashSource(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() {
+		return "", os.ErrNotExist
+	}
+	hashMu.Lock()
+	h, has := hashes[path]
+	hashMu.Unlock()
+	if has && h.modTime.Equal(info.ModTime()) && h.size == info.Size() {
+		return h.hash, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	h = &sourceHash{modTime: info.ModTime(), size: info.Size(), hash: hex.EncodeToString(sum[:10])}
+	hashMu.Lock()
+	hashes[path] = h
+	hashMu.Unlock()
+	return h.hash, nil
+}
+
+// flight: 같은 캐시 파일을 동시에 여러번 만들지 않도록. 먼저 시작한 요청이 끝나면 나머지는 결과를 같이 사용
+type flight struct {
+	done chan struct{}
+	err  error
+}
+
+var (
+	flightMu sync.Mutex
+	flights  = map[string]*flight{}
+)
+
+func once(key string, f func() error) error {
+	flightMu.Lock()
+	if fl, has := flights[key]; has {
+		flightMu.Unlock()
+		<-fl.done
+		return fl.err
+	}
+	fl := &flight{done: make(chan struct{})}
+	flights[key] = fl
+	flightMu.Unlock()
+
+	fl.err = f()
+	close(fl.done)
+	flightMu.Lock()
+	delete(flights, key)
+	flightMu.Unlock()
+	return fl.err
+}
+
+// Resized: 원본 src를 너비 w(원본보다 크면 원본 너비), format으로 변환한 캐시 파일 경로와 ETag로 쓸 키.
+// format이 빈 문자열이면 투명한 부분이 있을때 png, 아니면 jpg.
+// 캐시 파일 이름은 원본 내용의 해시라서 원본이 바뀌면 새로 만들어짐
+func Resized(src string, w int, format string) (path, key string, err error) {
+	if !IsResizeWidth(w) {
+		return "", "", fmt.Errorf("width: %d 지원하지 않는 너비입니다", w)
+	}
+	if format != "" && !CanEncode(format) {
+		return "", "", fmt.Errorf("format: %q 지원하지 않는 포맷입니다", format)
+	}
+	hash, err := hashSource(src)
+	if err != nil {
+		return "", "", err
+	}
+	dir := filepath.Join(CACHE_DIR, hash[:2])
+	formats := []string{format}
+	if format == "" {
+		formats = []string{FORMAT_JPEG, FORMAT_PNG}
+	}
+	// cached: 이미 만든 캐시 파일
+	cached := func() (string, string, bool) {
+		for _, f := range formats {
+			key := fmt.Sprintf("%s-%d.%s", hash, w, f)
+			if _, err := os.Stat(filepath.Join(dir, key)); err == nil {
+				return filepath.Join(dir, key), key, true
+			}
+		}
+		return "", "", false
+	}
+	if path, key, has := cached(); has {
+		return path, key, nil
+	}
+	err = once(fmt.Sprintf("%s-%d.%s", hash, w, format), func() error {
+		if _, _, has := cached(); has {
+			return nil
+		}
+		b, err := os.ReadFile(src)
+		if err != nil {
+			return err
+		}
+		m, err := decodeImage(b)
+		if err != nil {
+			return err
+		}
+		f := format
+		if f == "" {
+			f = FORMAT_JPEG
+			if !m.Opaque() {
+				f = FORMAT_PNG
+			}
+		}
+		return writeResized(m, w, f, filepath.Join(dir, fmt.Sprintf("%s-%d.%s", hash, w, f)))
+	})
+	if err != nil {
+		return "", "", err
+	}
+	if path, key, has := cached(); has {
+		return path, key, nil
+	}
+	return "", "", fmt.Errorf("%s: 캐시 파일을 만들지 못했습니다", src)
+}
+
+// tempPath: path와 같은 디렉토리의 임시 파일 이름. 같은 파일을 동시에 만들어도 겹치지 않도록
+func tempPath(path, ext string) (string, error) {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp."+ext)
+	if err != nil {
+		return "", err
+	}
+	f.Close()
+	return f.Name(), nil
+}
+
+// writeResized: m을 너비 w, format으로 path에 저장. 다 만든 뒤에 rename해서 만드는 도중의 파일을 다른 요청이 읽지 않도록
+func writeResized(m *image.NRGBA, w int, format, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	// webp, avif는 png로 저장한 뒤 변환
+	base := format
+	if format != FORMAT_JPEG && format != FORMAT_PNG {
+		base = FORMAT_PNG
+	}
+	tmp, err := tempPath(path, base)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	if err := encode(tmp, resize(m, w), base); err != nil {
+		return err
+	}
+	if base == format {
+		return os.Rename(tmp, path)
+	}
+	e := findEncoder(format)
+	if e == nil {
+		return fmt.Errorf("format: %q 지원하지 않는 포맷입니다", format)
+	}
+	out, err := tempPath(path, format)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(out)
+	if err := e.convert(tmp, out); err != nil {
+		return err
+	}
+	return os.Rename(out, path)
+}
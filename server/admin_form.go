@@ -0,0 +1,433 @@
+package server
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jeonghoikun/jinwoowide.com/store"
+)
+
+// storeForm: 관리자 업소 입력 폼. 목록 항목은 textarea에 한 줄에 하나씩 입력
+type storeForm struct {
+	Do          string `form:"do"`
+	Si          string `form:"si"`
+	Dong        string `form:"dong"`
+	Address     string `form:"address"`
+	RoadAddress string `form:"roadAddress"`
+	// Lat, Lng: 좌표. 둘 다 비어있으면 좌표 없음
+	Lat           string `form:"lat"`
+	Lng           string `form:"lng"`
+	Type          string `form:"type"`
+	Title         string `form:"title"`
+	Slug          string `form:"slug"`
+	FormerSlugs   string `form:"formerSlugs"`
+	Description   string `form:"description"`
+	DatePublished string `form:"datePublished"`
+	// PublishStatus: draft, scheduled, published, archived
+	PublishStatus string `form:"publishStatus"`
+	// PublishAt: 공개 시각. datetime-local 입력 ex) 2024-03-11T18:00
+	PublishAt string `form:"publishAt"`
+
+	Closed bool   `form:"closed"`
+	Reason string `form:"reason"`
+
+	Part1Has    bool   `form:"part1Has"`
+	Part1Open   string `form:"part1Open"`
+	Part1Closed string `form:"part1Closed"`
+	Part2Has    bool   `form:"part2Has"`
+	Part2Open   string `form:"part2Open"`
+	Part2Closed string `form:"part2Closed"`
+	// Weekly: ex) sun,sat | 18:00-05:00 | -
+	Weekly string `form:"weekly"`
+	// Holidays: ex) 2024-09-17
+	Holidays string `form:"holidays"`
+	// Closures: ex) 2024-09-01 | 2024-09-03 | 내부 수리
+	Closures string `form:"closures"`
+
+	VAT string `form:"vat"`
+	// Part1Bottles: ex) 양주 세트: 150000
+	Part1Bottles string `form:"part1Bottles"`
+	Part2Bottles string `form:"part2Bottles"`
+	TCPrice      string `form:"tcPrice"`
+	TCPer        string `form:"tcPer"`
+	RTPrice      string `form:"rtPrice"`
+	RTPer        string `form:"rtPer"`
+	// WeekendDays: ex) fri,sat. 비어있으면 주말 추가 요금 없음
+	WeekendDays  string `form:"weekendDays"`
+	WeekendPrice string `form:"weekendPrice"`
+	WeekendPer   string `form:"weekendPer"`
+
+	// Effective: 메뉴, 영업시간, 영업상태 변경 적용일. 수정할때만 사용
+	Effective string `form:"effective"`
+	Note      string `form:"note"`
+	// Amend: 변경 이력을 남기지 않고 현재 값을 고침(오타 수정 등)
+	Amend bool `form:"amend"`
+}
+
+func lines(v string) []string {
+	list := []string{}
+	for _, line := range strings.Split(v, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			list = append(list, line)
+		}
+	}
+	return list
+}
+
+func splitList(v, sep string) []string {
+	list := []string{}
+	for _, item := range strings.Split(v, sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// formatPrice: 폼에 표시하는 가격. 문의면 빈 문자열
+func formatPrice(p store.Price) string {
+	if !p.Known {
+		return ""
+	}
+	return strconv.Itoa(p.Amount)
+}
+
+// parsePrice: 빈 문자열이나 "문의"는 가격 미공개. ex) 150000, 150,000, ₩150,000
+func parsePrice(field, v string) (store.Price, error) {
+	v = strings.TrimSpace(v)
+	if v == "" || v == "문의" {
+		return store.Price{}, nil
+	}
+	n, err := strconv.Atoi(strings.NewReplacer(",", "", "₩", "").Replace(v))
+	if err != nil {
+		return store.Price{}, fmt.Errorf("%s: %q 가격은 숫자 또는 문의여야 합니다", field, v)
+	}
+	return store.Won(n), nil
+}
+
+func formatTime(t *store.TimeType) string {
+	if t == nil || !t.Has {
+		return "-"
+	}
+	return t.Open + "-" + t.Closed
+}
+
+// parseTime: "18:00-05:00" 또는 "-"(영업 안함)
+func parseTime(field, v string) (*store.TimeType, error) {
+	if v == "-" || v == "" {
+		return &store.TimeType{Has: false}, nil
+	}
+	open, closed, ok := strings.Cut(v, "-")
+	if !ok {
+		return nil, fmt.Errorf("%s: %q 18:00-05:00 또는 - 형식이어야 합니다", field, v)
+	}
+	return &store.TimeType{Has: true, Open: strings.TrimSpace(open), Closed: strings.TrimSpace(closed)}, nil
+}
+
+func formatDays(days []store.Weekday) string {
+	var ss []string
+	for _, d := range days {
+		ss = append(ss, d.String())
+	}
+	return strings.Join(ss, ",")
+}
+
+func parseDays(field, v string) ([]store.Weekday, error) {
+	var days []store.Weekday
+	for _, name := range splitList(v, ",") {
+		d, err := store.ParseWeekday(name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", field, err)
+		}
+		days = append(days, d)
+	}
+	return days, nil
+}
+
+func formatBottles(bottles []*store.Bottle) string {
+	var ss []string
+	for _, b := range bottles {
+		price := formatPrice(b.Price)
+		if price == "" {
+			price = "문의"
+		}
+		ss = append(ss, fmt.Sprintf("%s: %s", b.Name, price))
+	}
+	return strings.Join(ss, "\n")
+}
+
+func parseBottles(field, v string) ([]*store.Bottle, []error) {
+	var errs []error
+	bottles := []*store.Bottle{}
+	for i, line := range lines(v) {
+		name, price, _ := strings.Cut(line, ":")
+		p, err := parsePrice(fmt.Sprintf("%s %d번째 줄", field, i+1), price)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		bottles = append(bottles, &store.Bottle{Name: strings.TrimSpace(name), Price: p})
+	}
+	return bottles, errs
+}
+
+func timeFields(t *store.TimeType) (has bool, open, closed string) {
+	if t == nil {
+		return false, "", ""
+	}
+	return t.Has, t.Open, t.Closed
+}
+
+func (f *storeForm) setHour(h *store.Hour) {
+	f.Part1Has, f.Part1Open, f.Part1Closed = timeFields(h.Part1)
+	f.Part2Has, f.Part2Open, f.Part2Closed = timeFields(h.Part2)
+	var weekly, closures []string
+	for _, w := range h.Weekly {
+		weekly = append(weekly, fmt.Sprintf("%s | %s | %s", formatDays(w.Days), formatTime(w.Part1), formatTime(w.Part2)))
+	}
+	for _, c := range h.Closures {
+		closures = append(closures, fmt.Sprintf("%s | %s | %s", c.From, c.To, c.Reason))
+	}
+	f.Weekly = strings.Join(weekly, "\n")
+	f.Holidays = strings.Join(h.Holidays, "\n")
+	f.Closures = strings.Join(closures, "\n")
+}
+
+func (f *storeForm) hour() (*store.Hour, []error) {
+	var errs []error
+	h := &store.Hour{
+		Part1: &store.TimeType{Has: f.Part1Has},
+		Part2: &store.TimeType{Has: f.Part2Has},
+	}
+	if f.Part1Has {
+		h.Part1.Open, h.Part1.Closed = f.Part1Open, f.Part1Closed
+	}
+	if f.Part2Has {
+		h.Part2.Open, h.Part2.Closed = f.Part2Open, f.Part2Closed
+	}
+	for i, line := range lines(f.Weekly) {
+		field := fmt.Sprintf("요일별 영업시간 %d번째 줄", i+1)
+		cols := strings.Split(line, "|")
+		if len(cols) != 3 {
+			errs = append(errs, fmt.Errorf("%s: 요일 | 1부 | 2부 형식이어야 합니다", field))
+			continue
+		}
+		days, err := parseDays(field, cols[0])
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		part1, err := parseTime(field, strings.TrimSpace(cols[1]))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		part2, err := parseTime(field, strings.TrimSpace(cols[2]))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		h.Weekly = append(h.Weekly, &store.WeeklyHour{Days: days, Part1: part1, Part2: part2})
+	}
+	h.Holidays = lines(f.Holidays)
+	for i, line := range lines(f.Closures) {
+		cols := strings.SplitN(line, "|", 3)
+		if len(cols) < 2 {
+			errs = append(errs, fmt.Errorf("임시휴업 %d번째 줄: 시작일 | 종료일 | 사유 형식이어야 합니다", i+1))
+			continue
+		}
+		c := &store.Closure{From: strings.TrimSpace(cols[0]), To: strings.TrimSpace(cols[1])}
+		if len(cols) == 3 {
+			c.Reason = strings.TrimSpace(cols[2])
+		}
+		h.Closures = append(h.Closures, c)
+	}
+	return h, errs
+}
+
+func (f *storeForm) setMenu(m *store.Menu) {
+	f.VAT = m.VAT
+	f.Part1Bottles = formatBottles(m.Part1)
+	f.Part2Bottles = formatBottles(m.Part2)
+	if m.TC != nil {
+		f.TCPrice, f.TCPer = formatPrice(m.TC.Price), string(m.TC.Per)
+	}
+	if m.RT != nil {
+		f.RTPrice, f.RTPer = formatPrice(m.RT.Price), string(m.RT.Per)
+	}
+	if m.Weekend != nil {
+		f.WeekendDays = formatDays(m.Weekend.Days)
+		f.WeekendPrice, f.WeekendPer = formatPrice(m.Weekend.Price), string(m.Weekend.Per)
+	}
+}
+
+func (f *storeForm) menu() (*store.Menu, []error) {
+	var errs []error
+	m := &store.Menu{Currency: "KRW", VAT: f.VAT}
+	var bottleErrs []error
+	m.Part1, bottleErrs = parseBottles("1부 주대", f.Part1Bottles)
+	errs = append(errs, bottleErrs...)
+	m.Part2, bottleErrs = parseBottles("2부 주대", f.Part2Bottles)
+	errs = append(errs, bottleErrs...)
+	tc, err := parsePrice("TC", f.TCPrice)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	m.TC = &store.Charge{Price: tc, Per: store.ChargeUnit(f.TCPer)}
+	rt, err := parsePrice("RT", f.RTPrice)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	m.RT = &store.Charge{Price: rt, Per: store.ChargeUnit(f.RTPer)}
+	if strings.TrimSpace(f.WeekendDays) != "" {
+		days, err := parseDays("주말 추가 요일", f.WeekendDays)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		price, err := parsePrice("주말 추가 요금", f.WeekendPrice)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		m.Weekend = &store.Surcharge{Days: days, Charge: store.Charge{Price: price, Per: store.ChargeUnit(f.WeekendPer)}}
+	}
+	return m, errs
+}
+
+// datetime-local 입력 형식
+const publishAtInputLayout = "2006-01-02T15:04"
+
+// formatPublishAt: data 파일의 공개 시각(2024-03-11 18:00)을 datetime-local 입력 형식으로
+func formatPublishAt(v string) string { return strings.Replace(v, " ", "T", 1) }
+
+// parsePublishAt: datetime-local 입력을 data 파일 형식으로. 빈 문자열은 공개 시각 없음
+func parsePublishAt(v string) (string, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return "", nil
+	}
+	t, err := time.Parse(publishAtInputLayout, v)
+	if err != nil {
+		return "", fmt.Errorf("공개 시각: %q 2024-03-11T18:00 형식이어야 합니다", v)
+	}
+	return t.Format("2006-01-02 15:04"), nil
+}
+
+// parseGeo: 위도, 경도 입력. 둘 다 비어있으면 nil
+func parseGeo(lat, lng string) (*store.Geo, error) {
+	lat, lng = strings.TrimSpace(lat), strings.TrimSpace(lng)
+	if lat == "" && lng == "" {
+		return nil, nil
+	}
+	if lat == "" || lng == "" {
+		return nil, fmt.Errorf("좌표: 위도와 경도를 모두 입력해야 합니다")
+	}
+	g := &store.Geo{}
+	var err error
+	if g.Lat, err = strconv.ParseFloat(lat, 64); err != nil {
+		return nil, fmt.Errorf("위도: %q 숫자여야 합니다 (ex. 37.4954171)", lat)
+	}
+	if g.Lng, err = strconv.ParseFloat(lng, 64); err != nil {
+		return nil, fmt.Errorf("경도: %q 숫자여야 합니다 (ex. 127.0266819)", lng)
+	}
+	return g, nil
+}
+
+// newStoreForm: r의 now 시점 값으로 채운 폼
+func newStoreForm(r *store.Record, now time.Time) *storeForm {
+	f := &storeForm{
+		Do:            r.Location.Do,
+		Si:            r.Location.Si,
+		Dong:          r.Location.Dong,
+		Address:       r.Location.Address,
+		RoadAddress:   r.Location.RoadAddress,
+		Type:          r.Type,
+		Title:         r.Title,
+		Slug:          r.Slug,
+		FormerSlugs:   strings.Join(r.FormerSlugs, ", "),
+		Description:   r.Description,
+		DatePublished: r.DatePublished,
+		PublishStatus: r.PublishStatus,
+		PublishAt:     formatPublishAt(r.PublishAt),
+		Effective:     now.Format("2006-01-02"),
+	}
+	if f.PublishStatus == "" {
+		f.PublishStatus = string(store.PUBLISH_PUBLISHED)
+	}
+	// 아직 geo로 옮기지 않은 업소는 googleMapSrc의 좌표. 저장하면 geo로 옮겨짐
+	if g := r.Location.LatLng(); g != nil {
+		f.Lat = strconv.FormatFloat(g.Lat, 'f', -1, 64)
+		f.Lng = strconv.FormatFloat(g.Lng, 'f', -1, 64)
+	}
+	menu, hour, active := r.At(now)
+	f.Closed, f.Reason = active.IsPermanentClosed, active.Reason
+	f.setHour(hour)
+	f.setMenu(menu)
+	return f
+}
+
+// emptyStoreForm: 새 업소 폼의 기본값. 내용을 다 채우기 전에 공개되지 않도록 작성중
+func emptyStoreForm(now time.Time) *storeForm {
+	return &storeForm{
+		DatePublished: now.Format("2006-01-02"),
+		PublishStatus: string(store.PUBLISH_DRAFT),
+		Part1Has:      true,
+		Part1Open:     "18:00",
+		Part1Closed:   "05:00",
+		TCPer:         string(store.PER_PERSON),
+		RTPer:         string(store.PER_ROOM),
+		WeekendPer:    string(store.PER_ROOM),
+		Effective:     now.Format("2006-01-02"),
+	}
+}
+
+// record: 폼 내용으로 만든 data 파일 내용. 메뉴, 영업시간, 영업상태는 등록시점 값으로 채워짐
+func (f *storeForm) record() (*store.Record, []error) {
+	var errs []error
+	hour, hourErrs := f.hour()
+	errs = append(errs, hourErrs...)
+	menu, menuErrs := f.menu()
+	errs = append(errs, menuErrs...)
+	publishAt, err := parsePublishAt(f.PublishAt)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	geo, err := parseGeo(f.Lat, f.Lng)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	r := &store.Record{
+		Schema: "../../../../../store.schema.json",
+		Location: &store.Location{
+			Do:          strings.TrimSpace(f.Do),
+			Si:          strings.TrimSpace(f.Si),
+			Dong:        strings.TrimSpace(f.Dong),
+			Address:     strings.TrimSpace(f.Address),
+			RoadAddress: strings.TrimSpace(f.RoadAddress),
+			Geo:         geo,
+		},
+		Type:          f.Type,
+		Title:         strings.TrimSpace(f.Title),
+		Slug:          strings.TrimSpace(f.Slug),
+		FormerSlugs:   splitList(f.FormerSlugs, ","),
+		Description:   strings.TrimSpace(f.Description),
+		Active:        &store.Active{IsPermanentClosed: f.Closed, Reason: strings.TrimSpace(f.Reason)},
+		Hour:          hour,
+		Menu:          menu,
+		DatePublished: strings.TrimSpace(f.DatePublished),
+		PublishStatus: f.PublishStatus,
+		PublishAt:     publishAt,
+	}
+	// 기본값인 published는 data 파일에 적지 않음
+	if r.PublishStatus == string(store.PUBLISH_PUBLISHED) && r.PublishAt == "" {
+		r.PublishStatus = ""
+	}
+	if !r.Active.IsPermanentClosed {
+		r.Active.Reason = ""
+	}
+	if len(r.FormerSlugs) == 0 {
+		r.FormerSlugs = nil
+	}
+	return r, errs
+}
@@ -0,0 +1,254 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jeonghoikun/jinwoowide.com/store"
+	"github.com/jeonghoikun/jinwoowide.com/user"
+)
+
+type adminHandler struct{}
+
+// GET /admin/login
+func (*adminHandler) loginPage(c *fiber.Ctx) error {
+	return c.Status(http.StatusOK).Render("admin/login", fiber.Map{}, "layout/admin")
+}
+
+// POST /admin/login
+// 로그인 실패는 401. loginLimiter가 실패한 요청만 셈
+func (*adminHandler) login(c *fiber.Ctx) error {
+	name := strings.TrimSpace(c.FormValue("name"))
+	u, err := user.Authenticate(name, c.FormValue("password"))
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).Render("admin/login",
+			fiber.Map{"Name": name, "Errors": []string{err.Error()}}, "layout/admin")
+	}
+	if err := startSession(c, u.Name); err != nil {
+		return c.Status(http.StatusInternalServerError).SendString(err.Error())
+	}
+	return c.Redirect("/admin", http.StatusSeeOther)
+}
+
+// 로그인 실패 횟수 초과
+func (*adminHandler) loginLimited(c *fiber.Ctx) error {
+	return c.Status(http.StatusTooManyRequests).Render("admin/login",
+		fiber.Map{"Errors": []string{"로그인 실패 횟수가 많습니다. 10분 후에 다시 시도하세요"}}, "layout/admin")
+}
+
+// POST /admin/logout
+func (*adminHandler) logout(c *fiber.Ctx) error {
+	sess, err := adminSessions.Get(c)
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).SendString(err.Error())
+	}
+	if err := sess.Destroy(); err != nil {
+		return c.Status(http.StatusInternalServerError).SendString(err.Error())
+	}
+	return c.Redirect("/admin/login", http.StatusSeeOther)
+}
+
+func errSlugInUse(slug string) error {
+	return fmt.Errorf("slug: %q 는 다른 업소에서 사용중입니다", slug)
+}
+
+func errorMessages(errs []error) []string {
+	var ss []string
+	for _, err := range errs {
+		ss = append(ss, strings.Split(err.Error(), "\n")...)
+	}
+	return ss
+}
+
+// renderForm: 업소 입력 폼. s가 nil이면 새 업소
+func (*adminHandler) renderForm(c *fiber.Ctx, status int, s *store.Store, f *storeForm, errs []error) error {
+	m := fiber.Map{
+		"Title":      "새 업소",
+		"Action":     "/admin/stores",
+		"Form":       f,
+		"Errors":     errorMessages(errs),
+		"StoreTypes": store.StoreTypes(),
+		"Statuses":   store.PublishStatuses(),
+		"Saved":      c.Query("saved") != "",
+	}
+	if s != nil {
+		m["Title"] = s.Title + " 수정"
+		m["Action"] = "/admin/stores/" + s.Slug
+		m["Store"] = s
+		m["Report"] = s.Check()
+		// 공개하지 않은 업소는 로그인 없이 볼 수 있는 미리보기 링크
+		if !s.Public() {
+			u, err := previewURL(s.Path(), s.Slug, time.Now())
+			if err != nil {
+				return c.Status(http.StatusInternalServerError).SendString(err.Error())
+			}
+			m["PreviewURL"] = u
+		}
+	}
+	return c.Status(status).Render("admin/form", m, "layout/admin")
+}
+
+// GET /admin
+// 공개하지 않은 업소까지 모두 표시
+func (*adminHandler) index(c *fiber.Ctx) error {
+	all := catalogOf(c).ListAllStoresIncludingHidden()
+	stores := make([]*store.Store, 0, len(all))
+	for i := len(all) - 1; i >= 0; i-- {
+		stores = append(stores, all[i])
+	}
+	// 업소별 콘텐츠 점검 결과. key: slug
+	reports := make(map[string]*store.Report, len(all))
+	for _, r := range catalogOf(c).Reports(false) {
+		reports[r.Slug] = r
+	}
+	return c.Status(http.StatusOK).Render("admin/index", fiber.Map{"Stores": stores, "Reports": reports}, "layout/admin")
+}
+
+// GET /admin/reports
+// 콘텐츠 점검 결과 JSON. 기본은 빠진 콘텐츠가 있는 업소만, ?all=1이면 모든 업소
+func (*adminHandler) reports(c *fiber.Ctx) error {
+	reports := catalogOf(c).Reports(c.Query("all") == "")
+	return c.Status(http.StatusOK).JSON(fiber.Map{"count": len(reports), "stores": reports})
+}
+
+// GET /admin/stores/new
+func (h *adminHandler) newPage(c *fiber.Ctx) error {
+	return h.renderForm(c, http.StatusOK, nil, emptyStoreForm(time.Now()), nil)
+}
+
+// POST /admin/stores
+func (h *adminHandler) create(c *fiber.Ctx) error {
+	f := &storeForm{}
+	if err := c.BodyParser(f); err != nil {
+		return c.Status(http.StatusBadRequest).SendString(err.Error())
+	}
+	r, errs := f.record()
+	if len(errs) > 0 {
+		return h.renderForm(c, http.StatusBadRequest, nil, f, errs)
+	}
+	if _, has := catalogOf(c).GetBySlugIncludingHidden(r.Slug); has {
+		return h.renderForm(c, http.StatusBadRequest, nil, f, []error{errSlugInUse(r.Slug)})
+	}
+	if err := store.Save(r, nil); err != nil {
+		return h.renderForm(c, http.StatusBadRequest, nil, f, []error{err})
+	}
+	return c.Redirect("/admin/stores/"+r.Slug+"?saved=1", http.StatusSeeOther)
+}
+
+// adminStore: 현재 slug의 업소. 공개하지 않은 업소도 찾고 이전 slug는 찾지 않음
+func adminStore(c *fiber.Ctx) (*store.Store, bool) {
+	s, has := catalogOf(c).GetBySlugIncludingHidden(c.Params("slug"))
+	if !has || s.Slug != c.Params("slug") {
+		return nil, false
+	}
+	return s, true
+}
+
+// GET /admin/stores/:slug
+func (h *adminHandler) editPage(c *fiber.Ctx) error {
+	s, has := adminStore(c)
+	if !has {
+		return c.Status(http.StatusNotFound).SendString("Store not found")
+	}
+	r, err := s.Record()
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).SendString(err.Error())
+	}
+	return h.renderForm(c, http.StatusOK, s, newStoreForm(r, time.Now()), nil)
+}
+
+// POST /admin/stores/:slug
+// 메뉴, 영업시간, 영업상태가 바뀌면 적용일의 변경 이력으로 저장. Amend면 이력 없이 현재 값을 고침
+func (h *adminHandler) update(c *fiber.Ctx) error {
+	s, has := adminStore(c)
+	if !has {
+		return c.Status(http.StatusNotFound).SendString("Store not found")
+	}
+	f := &storeForm{}
+	if err := c.BodyParser(f); err != nil {
+		return c.Status(http.StatusBadRequest).SendString(err.Error())
+	}
+	next, errs := f.record()
+	if len(errs) > 0 {
+		return h.renderForm(c, http.StatusBadRequest, s, f, errs)
+	}
+	if other, has := catalogOf(c).GetBySlugIncludingHidden(next.Slug); has && other != s {
+		return h.renderForm(c, http.StatusBadRequest, s, f, []error{errSlugInUse(next.Slug)})
+	}
+	r, err := s.Record()
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).SendString(err.Error())
+	}
+	r.Location = next.Location
+	r.Type = next.Type
+	r.Title = next.Title
+	r.Slug = next.Slug
+	r.FormerSlugs = next.FormerSlugs
+	r.Description = next.Description
+	r.DatePublished = next.DatePublished
+	r.PublishStatus = next.PublishStatus
+	r.PublishAt = next.PublishAt
+	// slug를 바꾸면 이전 slug는 store.Save에서 FormerSlugs에 추가됨
+	if f.Amend {
+		r.Amend(time.Now(), next.Menu, next.Hour, next.Active)
+	} else if _, err := r.Revise(f.Effective, f.Note, next.Menu, next.Hour, next.Active); err != nil {
+		return h.renderForm(c, http.StatusBadRequest, s, f, []error{err})
+	}
+	if err := store.Save(r, s); err != nil {
+		return h.renderForm(c, http.StatusBadRequest, s, f, []error{err})
+	}
+	return c.Redirect("/admin/stores/"+r.Slug+"?saved=1", http.StatusSeeOther)
+}
+
+// POST /admin/stores/:slug/close
+// 폐업 처리. 적용일(기본 오늘)부터 폐업으로 변경 이력에 남김
+func (h *adminHandler) close(c *fiber.Ctx) error {
+	s, has := adminStore(c)
+	if !has {
+		return c.Status(http.StatusNotFound).SendString("Store not found")
+	}
+	reason := strings.TrimSpace(c.FormValue("reason"))
+	effective := c.FormValue("effective", time.Now().Format("2006-01-02"))
+	r, err := s.Record()
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).SendString(err.Error())
+	}
+	if _, err := r.Revise(effective, "폐업", nil, nil, &store.Active{IsPermanentClosed: true, Reason: reason}); err != nil {
+		return c.Status(http.StatusBadRequest).SendString(err.Error())
+	}
+	if err := store.Save(r, s); err != nil {
+		return c.Status(http.StatusBadRequest).SendString(err.Error())
+	}
+	return c.Redirect("/admin/stores/"+s.Slug+"?saved=1", http.StatusSeeOther)
+}
+
+// BaseURL = /admin
+// 로그인한 editor, admin 모두 업소를 관리할 수 있고 계정 관리는 admin만
+func handleAdmin(r fiber.Router) {
+	h := &adminHandler{}
+	r.Use(adminCSRF(), bindCSRF)
+	r.Get("/login", h.loginPage)
+	r.Post("/login", loginLimiter(h.loginLimited), h.login)
+	r.Post("/logout", h.logout)
+
+	r.Use(requireLogin)
+	r.Get("/", h.index)
+	r.Get("/reports", h.reports)
+	r.Get("/stores/new", h.newPage)
+	r.Post("/stores", h.create)
+	r.Get("/stores/:slug", h.editPage)
+	r.Post("/stores/:slug", h.update)
+	r.Post("/stores/:slug/close", h.close)
+	r.Post("/stores/:slug/images", h.uploadImages)
+	r.Post("/stores/:slug/images/:name", h.updateImage)
+	r.Post("/stores/:slug/images/:name/delete", h.deleteImage)
+
+	users := r.Group("/users", requireAdmin)
+	users.Get("/", h.users)
+	users.Post("/", h.createUser)
+	users.Post("/:name", h.updateUser)
+	users.Post("/:name/delete", h.deleteUser)
+}
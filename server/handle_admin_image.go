@@ -0,0 +1,179 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jeonghoikun/jinwoowide.com/media"
+	"github.com/jeonghoikun/jinwoowide.com/store"
+)
+
+// 한번에 올릴 수 있는 이미지 수
+const MAX_UPLOAD_FILES = 10
+
+// imageDir: 업소 이미지 디렉토리의 파일 경로
+func imageDir(s *store.Store) string { return strings.TrimPrefix(s.ImageDir(), "/") }
+
+// renderGalleryError: 이미지 처리 에러를 업소 수정 화면에 표시
+func (h *adminHandler) renderGalleryError(c *fiber.Ctx, s *store.Store, errs []error) error {
+	r, err := s.Record()
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).SendString(err.Error())
+	}
+	return h.renderForm(c, http.StatusBadRequest, s, newStoreForm(r, time.Now()), errs)
+}
+
+func readUpload(c *fiber.Ctx, name string) ([][]byte, []string, error) {
+	form, err := c.MultipartForm()
+	if err != nil {
+		return nil, nil, fmt.Errorf("이미지를 선택하세요")
+	}
+	files := form.File[name]
+	if len(files) == 0 {
+		return nil, nil, fmt.Errorf("이미지를 선택하세요")
+	}
+	if len(files) > MAX_UPLOAD_FILES {
+		return nil, nil, fmt.Errorf("한번에 %d장까지 올릴 수 있습니다", MAX_UPLOAD_FILES)
+	}
+	var list [][]byte
+	var names []string
+	for _, fh := range files {
+		if fh.Size > media.MAX_UPLOAD_SIZE {
+			return nil, nil, fmt.Errorf("%s: 파일이 너무 큽니다. %dMB 이하만 올릴 수 있습니다", fh.Filename, media.MAX_UPLOAD_SIZE>>20)
+		}
+		f, err := fh.Open()
+		if err != nil {
+			return nil, nil, err
+		}
+		b, err := io.ReadAll(io.LimitReader(f, media.MAX_UPLOAD_SIZE+1))
+		f.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+		list = append(list, b)
+		names = append(names, fh.Filename)
+	}
+	return list, names, nil
+}
+
+// POST /admin/stores/:slug/images
+// 올린 이미지를 너비별로 저장하고 갤러리 끝에 추가. 한장이라도 실패하면 아무것도 추가하지 않음
+func (h *adminHandler) uploadImages(c *fiber.Ctx) error {
+	s, has := adminStore(c)
+	if !has {
+		return c.Status(http.StatusNotFound).SendString("Store not found")
+	}
+	uploads, filenames, err := readUpload(c, "images")
+	if err != nil {
+		return h.renderGalleryError(c, s, []error{err})
+	}
+	r, err := s.Record()
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).SendString(err.Error())
+	}
+	names := map[string]bool{}
+	for _, img := range r.Gallery {
+		names[img.Name] = true
+	}
+	dir := imageDir(s)
+	alt := strings.TrimSpace(c.FormValue("alt"))
+	var added []*media.Image
+	var errs []error
+	for i, b := range uploads {
+		if name := media.Name(b); names[name] {
+			errs = append(errs, fmt.Errorf("%s: 이미 올린 이미지입니다", filenames[i]))
+			continue
+		}
+		img, err := media.Process(b, dir)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %s", filenames[i], err))
+			continue
+		}
+		img.Alt = alt
+		names[img.Name] = true
+		added = append(added, img)
+	}
+	if len(errs) == 0 {
+		r.Gallery = append(r.Gallery, added...)
+		if err := store.Save(r, s); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		for _, img := range added {
+			media.Remove(dir, img)
+		}
+		return h.renderGalleryError(c, s, errs)
+	}
+	return c.Redirect("/admin/stores/"+s.Slug+"?saved=1#gallery", http.StatusSeeOther)
+}
+
+// galleryIndex: 갤러리에서 이름이 name인 이미지의 위치. 없으면 -1
+func galleryIndex(gallery []*media.Image, name string) int {
+	for i, img := range gallery {
+		if img.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// POST /admin/stores/:slug/images/:name
+// 설명 수정, move=up|down이면 순서 변경
+func (h *adminHandler) updateImage(c *fiber.Ctx) error {
+	s, has := adminStore(c)
+	if !has {
+		return c.Status(http.StatusNotFound).SendString("Store not found")
+	}
+	r, err := s.Record()
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).SendString(err.Error())
+	}
+	i := galleryIndex(r.Gallery, c.Params("name"))
+	if i < 0 {
+		return c.Status(http.StatusNotFound).SendString("Image not found")
+	}
+	r.Gallery[i].Alt = strings.TrimSpace(c.FormValue("alt"))
+	switch c.FormValue("move") {
+	case "up":
+		if i > 0 {
+			r.Gallery[i-1], r.Gallery[i] = r.Gallery[i], r.Gallery[i-1]
+		}
+	case "down":
+		if i < len(r.Gallery)-1 {
+			r.Gallery[i+1], r.Gallery[i] = r.Gallery[i], r.Gallery[i+1]
+		}
+	}
+	if err := store.Save(r, s); err != nil {
+		return h.renderGalleryError(c, s, []error{err})
+	}
+	return c.Redirect("/admin/stores/"+s.Slug+"?saved=1#gallery", http.StatusSeeOther)
+}
+
+// POST /admin/stores/:slug/images/:name/delete
+// 갤러리에서 빼고 파일도 지움
+func (h *adminHandler) deleteImage(c *fiber.Ctx) error {
+	s, has := adminStore(c)
+	if !has {
+		return c.Status(http.StatusNotFound).SendString("Store not found")
+	}
+	r, err := s.Record()
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).SendString(err.Error())
+	}
+	i := galleryIndex(r.Gallery, c.Params("name"))
+	if i < 0 {
+		return c.Status(http.StatusNotFound).SendString("Image not found")
+	}
+	img := r.Gallery[i]
+	r.Gallery = append(r.Gallery[:i], r.Gallery[i+1:]...)
+	if err := store.Save(r, s); err != nil {
+		return h.renderGalleryError(c, s, []error{err})
+	}
+	media.Remove(imageDir(s), img)
+	return c.Redirect("/admin/stores/"+s.Slug+"?saved=1#gallery", http.StatusSeeOther)
+}
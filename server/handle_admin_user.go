@@ -0,0 +1,77 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jeonghoikun/jinwoowide.com/user"
+)
+
+func (*adminHandler) renderUsers(c *fiber.Ctx, status int, errs []error) error {
+	m := fiber.Map{
+		"Users":  user.List(),
+		"Errors": errorMessages(errs),
+		"Saved":  c.Query("saved") != "",
+	}
+	return c.Status(status).Render("admin/users", m, "layout/admin")
+}
+
+// GET /admin/users
+func (h *adminHandler) users(c *fiber.Ctx) error { return h.renderUsers(c, http.StatusOK, nil) }
+
+// POST /admin/users
+func (h *adminHandler) createUser(c *fiber.Ctx) error {
+	role, err := user.ParseRole(c.FormValue("role"))
+	if err == nil {
+		err = user.Add(strings.TrimSpace(c.FormValue("name")), c.FormValue("password"), role)
+	}
+	if err != nil {
+		return h.renderUsers(c, http.StatusBadRequest, []error{err})
+	}
+	return c.Redirect("/admin/users?saved=1", http.StatusSeeOther)
+}
+
+// POST /admin/users/:name
+// role, password 중 입력한 항목만 변경. 자기 자신의 권한은 바꿀 수 없음.
+// 바꾼 계정의 기존 세션은 다시 로그인해야 함
+func (h *adminHandler) updateUser(c *fiber.Ctx) error {
+	name := c.Params("name")
+	if v := c.FormValue("role"); v != "" {
+		role, err := user.ParseRole(v)
+		if err != nil {
+			return h.renderUsers(c, http.StatusBadRequest, []error{err})
+		}
+		if name == currentUser(c).Name && role != currentUser(c).Role {
+			return h.renderUsers(c, http.StatusBadRequest, []error{fmt.Errorf("자기 자신의 권한은 바꿀 수 없습니다")})
+		}
+		if err := user.SetRole(name, role); err != nil {
+			return h.renderUsers(c, http.StatusBadRequest, []error{err})
+		}
+	}
+	if password := c.FormValue("password"); password != "" {
+		if err := user.SetPassword(name, password); err != nil {
+			return h.renderUsers(c, http.StatusBadRequest, []error{err})
+		}
+		// 다른 세션은 다시 로그인해야 하지만 지금 세션은 유지
+		if name == currentUser(c).Name {
+			if err := startSession(c, name); err != nil {
+				return c.Status(http.StatusInternalServerError).SendString(err.Error())
+			}
+		}
+	}
+	return c.Redirect("/admin/users?saved=1", http.StatusSeeOther)
+}
+
+// POST /admin/users/:name/delete
+func (h *adminHandler) deleteUser(c *fiber.Ctx) error {
+	name := c.Params("name")
+	if name == currentUser(c).Name {
+		return h.renderUsers(c, http.StatusBadRequest, []error{fmt.Errorf("자기 자신은 삭제할 수 없습니다")})
+	}
+	if err := user.Delete(name); err != nil {
+		return h.renderUsers(c, http.StatusBadRequest, []error{err})
+	}
+	return c.Redirect("/admin/users?saved=1", http.StatusSeeOther)
+}
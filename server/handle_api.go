@@ -0,0 +1,162 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jeonghoikun/jinwoowide.com/store"
+)
+
+// 견적 API에서 허용하는 최대 인원
+const quoteMaxPeople = 1000
+
+// 근처 업소 API 기본 반경(m), 기본 개수, 최대 개수
+const (
+	nearbyDefaultRadius = 1000
+	nearbyDefaultLimit  = 20
+	nearbyMaxLimit      = 100
+)
+
+type apiHandler struct{}
+
+func apiError(c *fiber.Ctx, status int, message string) error {
+	return c.Status(status).JSON(fiber.Map{"error": message})
+}
+
+func priceJSON(p store.Price) fiber.Map {
+	return fiber.Map{"price": p, "label": p.String(), "inquiry": !p.Known}
+}
+
+// GET /api/stores/:id/quote?people=N&part=1|2&day=sun..sat
+// id는 업소 slug. day를 주면 해당 요일의 추가 요금까지 포함
+func (*apiHandler) quote(c *fiber.Ctx) error {
+	s, has := catalogOf(c).GetBySlug(c.Params("id"))
+	if !has {
+		return apiError(c, http.StatusNotFound, "업소가 존재하지 않습니다")
+	}
+	people, err := strconv.Atoi(c.Query("people", "1"))
+	if err != nil || people < 1 || people > quoteMaxPeople {
+		return apiError(c, http.StatusBadRequest, fmt.Sprintf("people: 1 ~ %d 사이의 인원수를 입력하세요", quoteMaxPeople))
+	}
+	part, err := strconv.Atoi(c.Query("part", "1"))
+	if err != nil || (part != 1 && part != 2) {
+		return apiError(c, http.StatusBadRequest, "part: 1 또는 2를 입력하세요")
+	}
+	if !s.Hour.Part(part).Has {
+		return apiError(c, http.StatusBadRequest, "part: 영업하지 않는 부입니다")
+	}
+	var q *store.Quote
+	if day := c.Query("day"); day != "" {
+		weekday, err := store.ParseWeekday(day)
+		if err != nil {
+			return apiError(c, http.StatusBadRequest, "day: "+err.Error())
+		}
+		q = s.Menu.QuoteOn(part, people, weekday)
+	} else {
+		q = s.Menu.Quote(part, people)
+	}
+	items := []fiber.Map{}
+	for _, item := range q.Items {
+		m := priceJSON(item.Price)
+		m["name"] = item.Name
+		items = append(items, m)
+	}
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"store":    s.Slug,
+		"part":     q.Part,
+		"people":   q.People,
+		"currency": s.Menu.Currency,
+		"vat":      s.Menu.VAT,
+		"items":    items,
+		"total":    priceJSON(q.Total),
+	})
+}
+
+// GET /api/stores/:id/history
+// 최신 버전부터. 각 버전은 그 시점의 메뉴, 영업시간, 영업상태 전체
+func (*apiHandler) history(c *fiber.Ctx) error {
+	s, has := catalogOf(c).GetBySlug(c.Params("id"))
+	if !has {
+		return apiError(c, http.StatusNotFound, "업소가 존재하지 않습니다")
+	}
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"store":         s.Slug,
+		"datePublished": s.DatePublished,
+		"dateModified":  s.DateModified,
+		"history":       s.ListHistory(),
+	})
+}
+
+// GET /api/stores/nearby?lat=&lng=&radius=&type=&open=true&limit=
+// radius는 m 단위. 가까운 순서. open=true면 지금 영업중인 업소만
+// ParseFloat는 NaN도 읽으므로 범위 검사는 NaN이면 실패하도록 !(min <= v && v <= max) 형태로
+func (*apiHandler) nearby(c *fiber.Ctx) error {
+	lat, err := strconv.ParseFloat(c.Query("lat"), 64)
+	if err != nil || !(lat >= -90 && lat <= 90) {
+		return apiError(c, http.StatusBadRequest, "lat: -90 ~ 90 사이의 위도를 입력하세요")
+	}
+	lng, err := strconv.ParseFloat(c.Query("lng"), 64)
+	if err != nil || !(lng >= -180 && lng <= 180) {
+		return apiError(c, http.StatusBadRequest, "lng: -180 ~ 180 사이의 경도를 입력하세요")
+	}
+	radius, err := strconv.ParseFloat(c.Query("radius", strconv.Itoa(nearbyDefaultRadius)), 64)
+	if err != nil || !(radius > 0 && radius <= store.NEARBY_MAX_RADIUS) {
+		return apiError(c, http.StatusBadRequest, fmt.Sprintf("radius: 0 ~ %d 사이의 반경(m)을 입력하세요", store.NEARBY_MAX_RADIUS))
+	}
+	limit, err := strconv.Atoi(c.Query("limit", strconv.Itoa(nearbyDefaultLimit)))
+	if err != nil || limit < 1 || limit > nearbyMaxLimit {
+		return apiError(c, http.StatusBadRequest, fmt.Sprintf("limit: 1 ~ %d 사이의 개수를 입력하세요", nearbyMaxLimit))
+	}
+	q := store.NearbyQuery{Center: &store.Geo{Lat: lat, Lng: lng}, Radius: radius, Limit: limit}
+	if t := c.Query("type"); t != "" {
+		for _, name := range store.StoreTypes() {
+			if name == t {
+				q.Type = t
+			}
+		}
+		if q.Type == "" {
+			return apiError(c, http.StatusBadRequest, "type: 없는 업종입니다")
+		}
+	}
+	now := time.Now()
+	if c.QueryBool("open") {
+		q.OpenAt = now
+	}
+	stores := []fiber.Map{}
+	for _, n := range catalogOf(c).Nearby(q) {
+		s, g, status := n.Store, n.Store.Location.LatLng(), n.Store.StatusAt(now)
+		stores = append(stores, fiber.Map{
+			"slug":          s.Slug,
+			"title":         s.Title,
+			"type":          s.Type,
+			"path":          s.Path(),
+			"address":       strings.TrimSpace(fmt.Sprintf("%s %s %s %s", s.Location.Do, s.Location.Si, s.Location.Dong, s.Location.Address)),
+			"roadAddress":   s.Location.RoadAddress,
+			"lat":           g.Lat,
+			"lng":           g.Lng,
+			"distance":      math.Round(n.Distance),
+			"distanceLabel": n.DistanceLabel(),
+			"open":          status.Open,
+			"status":        status.Label,
+		})
+	}
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"lat":    lat,
+		"lng":    lng,
+		"radius": radius,
+		"stores": stores,
+	})
+}
+
+// BaseURL = /api
+func handleAPI(r fiber.Router) {
+	h := &apiHandler{}
+	r.Get("/stores/nearby", h.nearby)
+	r.Get("/stores/:id/quote", h.quote)
+	r.Get("/stores/:id/history", h.history)
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jeonghoikun/jinwoowide.com/store"
+)
+
+// apiApp: 빈 Catalog를 쓰는 /api 라우터
+func apiApp() *fiber.App {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("catalog", &store.Catalog{})
+		return c.Next()
+	})
+	handleAPI(app.Group("/api"))
+	return app
+}
+
+func TestNearbyQueryValidation(t *testing.T) {
+	app := apiApp()
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"lat=37.5&lng=127", http.StatusOK},
+		{"lat=37.5&lng=127&radius=20000&limit=100", http.StatusOK},
+		{"lat=NaN&lng=127", http.StatusBadRequest},
+		{"lat=37.5&lng=NaN", http.StatusBadRequest},
+		{"lat=37.5&lng=127&radius=NaN", http.StatusBadRequest},
+		{"lat=Inf&lng=127", http.StatusBadRequest},
+		{"lat=37.5&lng=-Inf", http.StatusBadRequest},
+		{"lat=37.5&lng=127&radius=+Inf", http.StatusBadRequest},
+		{"lat=91&lng=127", http.StatusBadRequest},
+		{"lat=37.5&lng=181", http.StatusBadRequest},
+		{"lat=37.5&lng=127&radius=0", http.StatusBadRequest},
+		{"lat=37.5&lng=127&radius=20001", http.StatusBadRequest},
+		{"lat=37.5&lng=127&limit=0", http.StatusBadRequest},
+		{"lat=37.5&lng=127&type=없음", http.StatusBadRequest},
+		{"lng=127", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		res, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/stores/nearby?"+tt.query, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		body := map[string]any{}
+		if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+			t.Errorf("%s: 응답이 JSON이 아닙니다: %v", tt.query, err)
+		}
+		res.Body.Close()
+		if res.StatusCode != tt.want {
+			t.Errorf("%s: status = %d, want %d (%v)", tt.query, res.StatusCode, tt.want, body)
+		}
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"sort"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
@@ -14,8 +13,88 @@ import (
 
 type categoryHandler struct{}
 
+// regionBreadcrumbs: 도부터 r까지 지역 허브 페이지 링크
+func regionBreadcrumbs(r *store.Region) []*Breadcrumb {
+	list := []*Breadcrumb{}
+	for ; r != nil; r = r.Parent {
+		list = append([]*Breadcrumb{{Name: r.Name, Path: r.Path()}}, list...)
+	}
+	return list
+}
+
+// regionPage: 지역 허브 페이지. 업종별 업소 수와 업종별 업소 목록
+func (*categoryHandler) regionPage(c *fiber.Ctx, region *store.Region) error {
+	if len(region.Stores) == 0 {
+		return c.Status(http.StatusNotFound).SendString("지역에 업소가 없습니다")
+	}
+	name := strings.Join(region.Names(), " ")
+	var shorts, counts []string
+	keywords := []string{fmt.Sprintf("%s 업소 목록", name)}
+	for r := region; r != nil; r = r.Parent {
+		shorts = append([]string{r.Short}, shorts...)
+	}
+	for _, category := range region.Categories {
+		counts = append(counts, fmt.Sprintf("%s %d곳", category.Name, len(category.Stores)))
+		keywords = append(keywords, fmt.Sprintf("%s %s", region.Short, category.Name))
+	}
+	m := fiber.Map{}
+	page := &PageConfig{
+		Path: region.Path(),
+		Author: &Author{
+			Name:        site.Config.Author,
+			ProfilePath: "/static/img/site/author/profile.png",
+		},
+		Title: fmt.Sprintf("[%s] 지역 업소 목록", strings.Join(shorts, " > ")),
+		Description: fmt.Sprintf("%s 지역에 %d개의 업소가 있습니다: %s",
+			name, len(region.Stores), strings.Join(counts, ", ")),
+		Keywords:      strings.Join(keywords, ","),
+		PhoneNumber:   site.Config.PhoneNumber,
+		DatePublished: site.Config.DatePublished,
+		DateModified:  region.DateModified(),
+		ThumbnailPath: "/static/img/site/thumbnail/thumb.png",
+	}
+	m["Profile"] = map[string]string{"PhoneNumber": region.Stores[0].PhoneNumber}
+	breadcrumbs := append(regionBreadcrumbs(region.Parent), &Breadcrumb{Name: region.Name})
+	page.Schema = []any{ldBreadcrumbs(page.Path, breadcrumbs), ldStores(page.Title, region.Stores)}
+	m["Page"] = page
+	m["Breadcrumbs"] = breadcrumbs
+	m["Region"] = region
+	return c.Status(http.StatusOK).Render("category/region", m, "layout/category")
+}
+
+// GET /category/:do
+func (h *categoryHandler) doPage(c *fiber.Ctx) error {
+	do, err := url.QueryUnescape(c.Params("do"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).SendString(err.Error())
+	}
+	region, has := catalogOf(c).Region(do)
+	if !has {
+		return c.Status(http.StatusNotFound).SendString("지역이 존재하지 않습니다")
+	}
+	return h.regionPage(c, region)
+}
+
+// GET /category/:do/:si
+func (h *categoryHandler) siPage(c *fiber.Ctx) error {
+	do, err := url.QueryUnescape(c.Params("do"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).SendString(err.Error())
+	}
+	si, err := url.QueryUnescape(c.Params("si"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).SendString(err.Error())
+	}
+	region, has := catalogOf(c).Region(do, si)
+	if !has {
+		return c.Status(http.StatusNotFound).SendString("지역이 존재하지 않습니다")
+	}
+	return h.regionPage(c, region)
+}
+
 // GET /category/:do/:si/:storeType
-func (*categoryHandler) listPage(c *fiber.Ctx) error {
+// 세번째 경로가 동 이름이면 동 허브 페이지. 동 이름과 업종 이름은 겹치지 않음(store.loadRegions에서 검사)
+func (h *categoryHandler) listPage(c *fiber.Ctx) error {
 	do, err := url.QueryUnescape(c.Params("do"))
 	if err != nil {
 		return c.Status(http.StatusBadRequest).SendString(err.Error())
@@ -28,20 +107,21 @@ func (*categoryHandler) listPage(c *fiber.Ctx) error {
 	if err != nil {
 		return c.Status(http.StatusBadRequest).SendString(err.Error())
 	}
-	listStores := store.ListStoresByDoSiAndStoreType(do, si, storeType)
-	if len(listStores) == 0 {
+	if dong, has := catalogOf(c).Region(do, si, storeType); has {
+		return h.regionPage(c, dong)
+	}
+	region, has := catalogOf(c).Region(do, si)
+	listStores := catalogOf(c).ListStoresByDoSiAndStoreType(do, si, storeType)
+	if !has || len(listStores) == 0 {
 		return c.Status(http.StatusNotFound).SendString("카테고리가 존재하지 않습니다")
 	}
-	sort.Slice(listStores, func(i, j int) bool {
-		return listStores[i].DatePublished.UnixNano() > listStores[j].DatePublished.UnixNano()
-	})
 	var storeNames []string
 	for _, s := range listStores {
 		storeNames = append(storeNames, s.Title)
 	}
-	si = strings.Replace(si, "구", "", -1)
+	si = region.Short
 	m := fiber.Map{}
-	m["Page"] = &PageConfig{
+	page := &PageConfig{
 		Path: c.Path(),
 		Author: &Author{
 			Name:        site.Config.Author,
@@ -60,7 +140,10 @@ func (*categoryHandler) listPage(c *fiber.Ctx) error {
 		ThumbnailPath: "/static/img/site/thumbnail/thumb.png",
 	}
 	m["Profile"] = map[string]string{"PhoneNumber": listStores[0].PhoneNumber}
-	m["Breadcrumbs"] = map[string]string{"StoreType": listStores[0].Type}
+	breadcrumbs := append(regionBreadcrumbs(region), &Breadcrumb{Name: storeType})
+	page.Schema = []any{ldBreadcrumbs(page.Path, breadcrumbs), ldStores(page.Title, listStores)}
+	m["Page"] = page
+	m["Breadcrumbs"] = breadcrumbs
 	m["Stores"] = listStores
 	return c.Status(http.StatusOK).Render("category/index", m, "layout/category")
 }
@@ -68,5 +151,7 @@ func (*categoryHandler) listPage(c *fiber.Ctx) error {
 // BaseURL = /category
 func handleCategory(r fiber.Router) {
 	h := &categoryHandler{}
+	r.Get("/:do", h.doPage)
+	r.Get("/:do/:si", h.siPage)
 	r.Get("/:do/:si/:storeType", h.listPage)
 }
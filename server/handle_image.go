@@ -0,0 +1,88 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jeonghoikun/jinwoowide.com/media"
+)
+
+type imageHandler struct{}
+
+// 변환할 수 있는 원본 확장자
+var resizableExts = map[string]bool{".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".webp": true}
+
+// acceptFormats: format 파라미터가 없을때 Accept 헤더로 정한 포맷 후보. 작은 포맷부터.
+// 마지막 빈 문자열은 media.Resized가 원본에 따라 jpg, png 중에 정함
+func acceptFormats(c *fiber.Ctx) []string {
+	accept := c.Get(fiber.HeaderAccept)
+	var formats []string
+	for _, format := range []string{media.FORMAT_AVIF, media.FORMAT_WEBP} {
+		if strings.Contains(accept, media.MimeType(format)) && media.CanEncode(format) {
+			formats = append(formats, format)
+		}
+	}
+	return append(formats, "")
+}
+
+// GET /image/:width/*
+// ./static 아래의 이미지를 너비 width로 줄여서 응답. ex) /image/480/img/store/서울/.../thumbnail.png
+// ?format=jpg|png|webp|avif 가 없으면 Accept 헤더에 따라 avif, webp. 둘 다 안되면 투명한 부분이 있을때 png, 아니면 jpg
+func (*imageHandler) resized(c *fiber.Ctx) error {
+	w, err := strconv.Atoi(c.Params("width"))
+	if err != nil || !media.IsResizeWidth(w) {
+		return c.Status(http.StatusBadRequest).SendString("지원하지 않는 너비입니다")
+	}
+	rel, err := url.PathUnescape(c.Params("*"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).SendString(err.Error())
+	}
+	rel = path.Clean("/" + rel)
+	if !strings.HasPrefix(rel, "/img/") || !resizableExts[strings.ToLower(path.Ext(rel))] {
+		return c.Status(http.StatusNotFound).SendString("Image not found")
+	}
+	src := filepath.Join("static", filepath.FromSlash(rel))
+
+	formats := []string{c.Query("format")}
+	if formats[0] == "" {
+		c.Vary(fiber.HeaderAccept)
+		formats = acceptFormats(c)
+	} else if !media.CanEncode(formats[0]) {
+		return c.Status(http.StatusBadRequest).SendString("지원하지 않는 포맷입니다")
+	}
+	var p, key string
+	for _, format := range formats {
+		// 인코더가 실패하면 다음 후보 포맷으로
+		if p, key, err = media.Resized(src, w, format); err == nil || errors.Is(err, os.ErrNotExist) {
+			break
+		}
+		log.Printf("image: %s: %s", src, err)
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		return c.Status(http.StatusNotFound).SendString("Image not found")
+	}
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).SendString("이미지를 변환할 수 없습니다")
+	}
+	etag := `"` + key + `"`
+	c.Set(fiber.HeaderETag, etag)
+	c.Set(fiber.HeaderCacheControl, "public, max-age=604800")
+	if c.Get(fiber.HeaderIfNoneMatch) == etag {
+		return c.SendStatus(http.StatusNotModified)
+	}
+	return c.SendFile(p)
+}
+
+// BaseURL = /image
+func handleImage(r fiber.Router) {
+	h := &imageHandler{}
+	r.Get("/:width/*", h.resized)
+}
@@ -4,17 +4,24 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/jeonghoikun/jinwoowide.com/site"
-	"github.com/jeonghoikun/jinwoowide.com/store"
+)
+
+// 업소 페이지 근처 업소 반경(m), 개수와 함께 보면 좋은 업소 개수
+const (
+	STORE_NEARBY_RADIUS = 2000
+	STORE_NEARBY_LIMIT  = 6
+	STORE_RELATED_LIMIT = 4
 )
 
 type storeHandler struct{}
 
 // GET /store/:do/:si/:dong/:type/:title
-func (*storeHandler) page(c *fiber.Ctx) error {
+// 이전 주소 형식. canonical 주소(/store/:slug)로 301 리다이렉트. 위치, 업종, 상호를 바꾼 업소는 바꾸기 전 주소도
+func (*storeHandler) redirectLegacyPath(c *fiber.Ctx) error {
 	do, err := url.QueryUnescape(c.Params("do"))
 	if err != nil {
 		return c.Status(http.StatusBadRequest).SendString(err.Error())
@@ -35,12 +42,49 @@ func (*storeHandler) page(c *fiber.Ctx) error {
 	if err != nil {
 		return c.Status(http.StatusBadRequest).SendString(err.Error())
 	}
-	store, has := store.Get(do, si, dong, storeType, storeTitle)
+	store, has := catalogOf(c).GetByFormerPath(do, si, dong, storeType, storeTitle)
 	if !has {
 		return c.Status(http.StatusNotFound).SendString("Store not found")
 	}
-	si = strings.Replace(si, "구", "", -1)
+	return c.Redirect(store.Path(), http.StatusMovedPermanently)
+}
+
+// GET /store/:slug
+// 공개하지 않은 업소는 ?preview= 미리보기 토큰이 있을때만. 공개 종료된 업소는 410
+func (*storeHandler) page(c *fiber.Ctx) error {
+	store, has := catalogOf(c).GetBySlug(c.Params("slug"))
+	preview := false
+	if !has {
+		hidden, found := catalogOf(c).GetBySlugIncludingHidden(c.Params("slug"))
+		switch {
+		case !found:
+			return c.Status(http.StatusNotFound).SendString("Store not found")
+		case validPreview(hidden.Slug, c.Query("preview"), time.Now()):
+			store, preview = hidden, true
+		case hidden.Archived():
+			return c.Status(http.StatusGone).SendString("공개가 종료된 업소입니다")
+		default:
+			return c.Status(http.StatusNotFound).SendString("Store not found")
+		}
+	}
+	// 이전 slug
+	if store.Slug != c.Params("slug") {
+		path := store.Path()
+		if preview {
+			path += "?preview=" + url.QueryEscape(c.Query("preview"))
+		}
+		return c.Redirect(path, http.StatusMovedPermanently)
+	}
+	if preview {
+		c.Set("X-Robots-Tag", "noindex, nofollow")
+		c.Set(fiber.HeaderCacheControl, "private, no-store")
+	}
+	si := store.Region.Si().Short
 	title := fmt.Sprintf("%s %s %s", si, store.Title, store.Type)
+	// Markdown 소개글 front matter의 title
+	if store.Article.Title != "" {
+		title = store.Article.Title
+	}
 	if store.Active.IsPermanentClosed {
 		title += fmt.Sprintf(" (폐업: %s)", store.Active.Reason)
 	} else {
@@ -48,7 +92,7 @@ func (*storeHandler) page(c *fiber.Ctx) error {
 	}
 	m := fiber.Map{
 		"Page": &PageConfig{
-			Path: c.Path(),
+			Path: store.Path(),
 			Author: &Author{
 				Name:        site.Config.Author,
 				ProfilePath: "/static/img/site/author/profile.png",
@@ -59,22 +103,28 @@ func (*storeHandler) page(c *fiber.Ctx) error {
 			PhoneNumber:   store.PhoneNumber,
 			DatePublished: store.DatePublished,
 			DateModified:  store.DateModified,
-			ThumbnailPath: fmt.Sprintf("/static/img/store/%s/%s/%s/%s/%s/thumbnail.png",
-				store.Location.Do, store.Location.Si, store.Location.Dong, store.Type, store.Title),
+			ThumbnailPath: store.ThumbnailPath(),
+			Schema: []any{
+				ldBreadcrumbs(store.Path(), append(regionBreadcrumbs(store.Region), &Breadcrumb{Name: store.Title})),
+				ldStore(store),
+			},
 		},
 		"Profile": map[string]string{
 			"PhoneNumber": store.PhoneNumber,
 		},
-		"Store":  store,
-		"SiMini": si,
+		"Store":   store,
+		"SiMini":  si,
+		"Preview": preview,
+		"Nearby":  catalogOf(c).NearbyStores(store, STORE_NEARBY_RADIUS, STORE_NEARBY_LIMIT),
+		"Related": catalogOf(c).Related(store, STORE_RELATED_LIMIT),
 	}
-	embedFilePath := fmt.Sprintf("store/%s/%s/%s/%s/%s",
-		store.Location.Do, store.Location.Si, store.Location.Dong, store.Type, store.Title)
-	return c.Status(http.StatusOK).Render(embedFilePath, m, "layout/store")
+	// 소개글은 Catalog를 만들때 변수를 바꾸고 변환해둔 HTML
+	return c.Status(http.StatusOK).Render("components/store/article", m, "layout/store")
 }
 
 // BaseURL = /store
 func handleStore(r fiber.Router) {
 	h := &storeHandler{}
-	r.Get("/:do/:si/:dong/:type/:title", h.page)
+	r.Get("/:slug", h.page)
+	r.Get("/:do/:si/:dong/:type/:title", h.redirectLegacyPath)
 }
@@ -0,0 +1,291 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jeonghoikun/jinwoowide.com/site"
+	"github.com/jeonghoikun/jinwoowide.com/store"
+)
+
+// schema.org 구조화 데이터. 페이지마다 필요한 항목을 만들어서 PageConfig.Schema에 넣음
+
+type ldGraph struct {
+	Context string `json:"@context"`
+	Graph   []any  `json:"@graph"`
+}
+
+type ldWebSite struct {
+	Type        string `json:"@type"`
+	ID          string `json:"@id"`
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+	InLanguage  string `json:"inLanguage"`
+}
+
+type ldPerson struct {
+	Type  string `json:"@type"`
+	Name  string `json:"name"`
+	Image string `json:"image,omitempty"`
+}
+
+type ldWebPage struct {
+	Type          string    `json:"@type"`
+	ID            string    `json:"@id"`
+	URL           string    `json:"url"`
+	Name          string    `json:"name"`
+	Description   string    `json:"description,omitempty"`
+	Image         string    `json:"image,omitempty"`
+	IsPartOf      *ldRef    `json:"isPartOf"`
+	Author        *ldPerson `json:"author,omitempty"`
+	DatePublished string    `json:"datePublished,omitempty"`
+	DateModified  string    `json:"dateModified,omitempty"`
+}
+
+type ldRef struct {
+	ID string `json:"@id"`
+}
+
+type ldListItem struct {
+	Type     string `json:"@type"`
+	Position int    `json:"position"`
+	Name     string `json:"name,omitempty"`
+	Item     string `json:"item,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+type ldBreadcrumbList struct {
+	Type  string        `json:"@type"`
+	Items []*ldListItem `json:"itemListElement"`
+}
+
+type ldItemList struct {
+	Type            string        `json:"@type"`
+	Name            string        `json:"name"`
+	NumberOfItems   int           `json:"numberOfItems"`
+	ItemListElement []*ldListItem `json:"itemListElement"`
+}
+
+type ldPostalAddress struct {
+	Type            string `json:"@type"`
+	AddressCountry  string `json:"addressCountry"`
+	AddressRegion   string `json:"addressRegion"`
+	AddressLocality string `json:"addressLocality"`
+	StreetAddress   string `json:"streetAddress,omitempty"`
+}
+
+type ldGeoCoordinates struct {
+	Type      string  `json:"@type"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+type ldOpeningHours struct {
+	Type      string   `json:"@type"`
+	DayOfWeek []string `json:"dayOfWeek"`
+	Opens     string   `json:"opens"`
+	Closes    string   `json:"closes"`
+}
+
+type ldLocalBusiness struct {
+	Type         string            `json:"@type"`
+	ID           string            `json:"@id"`
+	Name         string            `json:"name"`
+	URL          string            `json:"url"`
+	Description  string            `json:"description,omitempty"`
+	Image        []string          `json:"image,omitempty"`
+	Telephone    string            `json:"telephone,omitempty"`
+	Address      *ldPostalAddress  `json:"address"`
+	Geo          *ldGeoCoordinates `json:"geo,omitempty"`
+	HasMap       string            `json:"hasMap,omitempty"`
+	OpeningHours []*ldOpeningHours `json:"openingHoursSpecification,omitempty"`
+	PriceRange   string            `json:"priceRange,omitempty"`
+	Currencies   string            `json:"currenciesAccepted,omitempty"`
+}
+
+// ldDayOfWeek: store.Weekday 순서(일요일부터)
+var ldDayOfWeek = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+
+// jsonLD: graph를 <script type="application/ld+json">에 넣을 JSON으로. encoding/json이 <, >, &를 escape하므로 script 안에 그대로 넣어도 됨
+func jsonLD(graph ...any) template.JS {
+	b, err := json.Marshal(&ldGraph{Context: "https://schema.org", Graph: graph})
+	if err != nil {
+		log.Printf("jsonld: %s", err)
+		return ""
+	}
+	return template.JS(b)
+}
+
+// ldSite: 사이트 자체. 모든 페이지의 WebSite
+func ldSite() *ldWebSite {
+	return &ldWebSite{
+		Type:        "WebSite",
+		ID:          absURL("/") + "#website",
+		Name:        site.Config.Title,
+		URL:         absURL("/"),
+		Description: site.Config.Description,
+		InLanguage:  "ko-KR",
+	}
+}
+
+func ldDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// ldPage: 페이지 자체. 기존 Article 대신 모든 페이지의 WebPage
+func ldPage(p *PageConfig) *ldWebPage {
+	w := &ldWebPage{
+		Type:          "WebPage",
+		ID:            absURL(p.Path) + "#webpage",
+		URL:           absURL(p.Path),
+		Name:          p.Title,
+		Description:   p.Description,
+		IsPartOf:      &ldRef{ID: absURL("/") + "#website"},
+		DatePublished: ldDate(p.DatePublished),
+		DateModified:  ldDate(p.DateModified),
+	}
+	if p.ThumbnailPath != "" {
+		w.Image = absURL(p.ThumbnailPath)
+	}
+	if p.Author != nil {
+		w.Author = &ldPerson{Type: "Person", Name: p.Author.Name, Image: absURL(p.Author.ProfilePath)}
+	}
+	return w
+}
+
+// JSONLD: 페이지의 구조화 데이터. WebSite, WebPage와 Schema
+func (p *PageConfig) JSONLD() template.JS {
+	return jsonLD(append([]any{ldSite(), ldPage(p)}, p.Schema...)...)
+}
+
+// ldBreadcrumbs: 홈부터 list까지. Path가 비어있는 마지막 항목은 현재 페이지 path
+func ldBreadcrumbs(path string, list []*Breadcrumb) *ldBreadcrumbList {
+	items := []*ldListItem{{Type: "ListItem", Position: 1, Name: "홈", Item: absURL("/")}}
+	for _, b := range list {
+		p := b.Path
+		if p == "" {
+			p = path
+		}
+		items = append(items, &ldListItem{Type: "ListItem", Position: len(items) + 1, Name: b.Name, Item: absURL(p)})
+	}
+	return &ldBreadcrumbList{Type: "BreadcrumbList", Items: items}
+}
+
+// ldStores: 업소 목록 페이지의 ItemList
+func ldStores(name string, stores []*store.Store) *ldItemList {
+	items := make([]*ldListItem, 0, len(stores))
+	for i, s := range stores {
+		items = append(items, &ldListItem{Type: "ListItem", Position: i + 1, Name: s.Title, URL: absURL(s.Path())})
+	}
+	return &ldItemList{Type: "ItemList", Name: name, NumberOfItems: len(items), ItemListElement: items}
+}
+
+// ldBusinessType: 업종별 schema.org 타입
+func ldBusinessType(storeType string) string {
+	switch storeType {
+	case store.STORE_TYPE_CLUB, store.STORE_TYPE_HOBBA:
+		return "NightClub"
+	case store.STORE_TYPE_KARAOKE:
+		return "Karaoke"
+	}
+	return "BarOrPub"
+}
+
+func ldClock(c store.Clock) string { return (c % (24 * 60)).String() }
+
+// ldOpeningHoursOf: 요일별 영업시간. 여는 시간, 닫는 시간이 같은 요일끼리 묶음
+func ldOpeningHoursOf(h *store.Hour) []*ldOpeningHours {
+	var list []*ldOpeningHours
+	byTime := map[string]*ldOpeningHours{}
+	week := h.Week()
+	// 월요일부터
+	for i := 1; i <= 7; i++ {
+		d := i % 7
+		for _, sp := range week[d] {
+			key := fmt.Sprintf("%d-%d", sp.Open, sp.Closed)
+			if oh, has := byTime[key]; has {
+				oh.DayOfWeek = append(oh.DayOfWeek, ldDayOfWeek[d])
+				continue
+			}
+			oh := &ldOpeningHours{Type: "OpeningHoursSpecification", DayOfWeek: []string{ldDayOfWeek[d]}, Opens: ldClock(sp.Open), Closes: ldClock(sp.Closed)}
+			byTime[key] = oh
+			list = append(list, oh)
+		}
+	}
+	return list
+}
+
+// ldPriceRange: 주대 최저 ~ 최고. 가격이 공개된 주대가 없으면 빈 문자열
+func ldPriceRange(m *store.Menu) string {
+	var min, max store.Price
+	for part := 1; part <= 2; part++ {
+		for _, b := range m.Bottles(part) {
+			if !b.Price.Known {
+				continue
+			}
+			if !min.Known || b.Price.Amount < min.Amount {
+				min = b.Price
+			}
+			if !max.Known || b.Price.Amount > max.Amount {
+				max = b.Price
+			}
+		}
+	}
+	switch {
+	case !min.Known:
+		return ""
+	case min == max:
+		return min.String()
+	}
+	return fmt.Sprintf("%s ~ %s", min, max)
+}
+
+// ldStreetAddress: 도로명주소가 있으면 도로명주소, 없으면 동 지번
+func ldStreetAddress(l *store.Location) string {
+	if l.RoadAddress != "" {
+		return l.RoadAddress
+	}
+	return strings.TrimSpace(l.Dong + " " + l.Address)
+}
+
+// ldStore: 업소 페이지의 LocalBusiness
+func ldStore(s *store.Store) *ldLocalBusiness {
+	l := s.Location
+	b := &ldLocalBusiness{
+		Type:        ldBusinessType(s.Type),
+		ID:          absURL(s.Path()) + "#business",
+		Name:        s.Title,
+		URL:         absURL(s.Path()),
+		Description: s.Description,
+		Telephone:   s.PhoneNumber,
+		Address: &ldPostalAddress{
+			Type:            "PostalAddress",
+			AddressCountry:  "KR",
+			AddressRegion:   l.Do,
+			AddressLocality: l.Si,
+			StreetAddress:   ldStreetAddress(l),
+		},
+		PriceRange: ldPriceRange(s.Menu),
+		Currencies: s.Menu.Currency,
+	}
+	if g := l.LatLng(); g != nil {
+		b.Geo = &ldGeoCoordinates{Type: "GeoCoordinates", Latitude: g.Lat, Longitude: g.Lng}
+		b.HasMap = "https://www.google.com/maps/search/?api=1&query=" + g.String()
+	}
+	for _, img := range s.Images() {
+		b.Image = append(b.Image, absURL(img))
+	}
+	// 폐업한 업소는 영업시간을 표시하지 않음
+	if !s.Active.IsPermanentClosed {
+		b.OpeningHours = ldOpeningHoursOf(s.Hour)
+	}
+	return b
+}
@@ -0,0 +1,196 @@
+package server
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jeonghoikun/jinwoowide.com/site"
+	"github.com/jeonghoikun/jinwoowide.com/store"
+)
+
+func TestMain(m *testing.M) {
+	site.Init()
+	os.Exit(m.Run())
+}
+
+// jsonLDStore: 테스트용 업소. 18:00~05:00 1부, 일요일만 20:00~24:00
+func jsonLDStore(t *testing.T, title, address string, bottle store.Price) *store.Store {
+	t.Helper()
+	s := &store.Store{
+		Location: &store.Location{
+			Do: "서울", Si: "강남구", Dong: "역삼동", Address: address,
+			Geo: &store.Geo{Lat: 37.4946097, Lng: 127.0297203},
+		},
+		Type:        store.STORE_TYPE_DOT5,
+		Title:       title,
+		Slug:        "yeoksam-jjeomo-test",
+		PhoneNumber: "010-0000-0000",
+		Active:      &store.Active{},
+		Hour: &store.Hour{
+			Part1: &store.TimeType{Has: true, Open: "18:00", Closed: "05:00"},
+			Part2: &store.TimeType{Has: false},
+			Weekly: []*store.WeeklyHour{{
+				Days:  []store.Weekday{store.Weekday(time.Sunday)},
+				Part1: &store.TimeType{Has: true, Open: "20:00", Closed: "24:00"},
+				Part2: &store.TimeType{Has: false},
+			}},
+		},
+		Menu: &store.Menu{
+			Currency: "KRW",
+			Part1:    []*store.Bottle{{Name: "양주 세트", Price: bottle}},
+			TC:       &store.Charge{Price: store.Price{}, Per: store.PER_PERSON},
+			RT:       &store.Charge{Price: store.Price{}, Per: store.PER_ROOM},
+		},
+	}
+	// 영업시간은 검사하면서 요일별로 계산됨
+	if err := store.Validate([]*store.Store{s}); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// decode: v를 JSON으로 바꿨다가 map으로 읽음
+func decode(t *testing.T, v any) map[string]any {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := map[string]any{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestLDStore(t *testing.T) {
+	s := jsonLDStore(t, "테스트", "831", store.Won(150000))
+	got := decode(t, ldStore(s))
+	if got["@type"] != "BarOrPub" {
+		t.Errorf("@type = %v, want BarOrPub", got["@type"])
+	}
+	wantAddress := map[string]any{
+		"@type":           "PostalAddress",
+		"addressCountry":  "KR",
+		"addressRegion":   "서울",
+		"addressLocality": "강남구",
+		"streetAddress":   "역삼동 831",
+	}
+	if !reflect.DeepEqual(got["address"], wantAddress) {
+		t.Errorf("address = %v, want %v", got["address"], wantAddress)
+	}
+	wantGeo := map[string]any{"@type": "GeoCoordinates", "latitude": 37.4946097, "longitude": 127.0297203}
+	if !reflect.DeepEqual(got["geo"], wantGeo) {
+		t.Errorf("geo = %v, want %v", got["geo"], wantGeo)
+	}
+	if got["priceRange"] != "₩150,000" {
+		t.Errorf("priceRange = %v, want ₩150,000", got["priceRange"])
+	}
+	// 월요일부터 같은 시간끼리 묶음. 자정을 넘기는 영업은 다음날 마감 시각, 24:00은 00:00
+	wantHours := []any{
+		map[string]any{
+			"@type":     "OpeningHoursSpecification",
+			"dayOfWeek": []any{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+			"opens":     "18:00",
+			"closes":    "05:00",
+		},
+		map[string]any{
+			"@type":     "OpeningHoursSpecification",
+			"dayOfWeek": []any{"Sunday"},
+			"opens":     "20:00",
+			"closes":    "00:00",
+		},
+	}
+	if !reflect.DeepEqual(got["openingHoursSpecification"], wantHours) {
+		t.Errorf("openingHoursSpecification = %v, want %v", got["openingHoursSpecification"], wantHours)
+	}
+}
+
+func TestLDStoreRoadAddress(t *testing.T) {
+	s := jsonLDStore(t, "테스트", "831", store.Won(150000))
+	s.Location.RoadAddress = "테헤란로 123"
+	address := decode(t, ldStore(s))["address"].(map[string]any)
+	if address["streetAddress"] != "테헤란로 123" {
+		t.Errorf("streetAddress = %v, want 테헤란로 123", address["streetAddress"])
+	}
+}
+
+func TestLDStoreInquiryPrice(t *testing.T) {
+	s := jsonLDStore(t, "테스트", "831", store.Price{})
+	got := decode(t, ldStore(s))
+	// 가격이 모두 문의면 priceRange를 넣지 않음
+	if v, has := got["priceRange"]; has {
+		t.Errorf("priceRange = %v, want 없음", v)
+	}
+}
+
+func TestLDStoreClosed(t *testing.T) {
+	s := jsonLDStore(t, "테스트", "831", store.Won(150000))
+	s.Active = &store.Active{IsPermanentClosed: true, Reason: "정상폐업"}
+	if v, has := decode(t, ldStore(s))["openingHoursSpecification"]; has {
+		t.Errorf("openingHoursSpecification = %v, want 없음", v)
+	}
+}
+
+func TestLDBreadcrumbs(t *testing.T) {
+	list := []*Breadcrumb{
+		{Name: "서울", Path: "/category/서울"},
+		{Name: "강남구", Path: "/category/서울/강남구"},
+		{Name: "테스트"},
+	}
+	got := ldBreadcrumbs("/store/yeoksam-jjeomo-test", list)
+	want := []struct {
+		name, item string
+	}{
+		{"홈", "https://jinwoowide.com/"},
+		{"서울", "https://jinwoowide.com/category/%EC%84%9C%EC%9A%B8"},
+		{"강남구", "https://jinwoowide.com/category/%EC%84%9C%EC%9A%B8/%EA%B0%95%EB%82%A8%EA%B5%AC"},
+		{"테스트", "https://jinwoowide.com/store/yeoksam-jjeomo-test"},
+	}
+	if len(got.Items) != len(want) {
+		t.Fatalf("itemListElement 개수 = %d, want %d", len(got.Items), len(want))
+	}
+	for i, w := range want {
+		item := got.Items[i]
+		if item.Position != i+1 || item.Name != w.name || item.Item != w.item {
+			t.Errorf("itemListElement[%d] = {%d %s %s}, want {%d %s %s}", i, item.Position, item.Name, item.Item, i+1, w.name, w.item)
+		}
+	}
+}
+
+func TestLDStores(t *testing.T) {
+	a := jsonLDStore(t, "에이", "1", store.Won(100000))
+	b := jsonLDStore(t, "비", "2", store.Won(100000))
+	b.Slug = "yeoksam-jjeomo-b"
+	got := ldStores("강남 쩜오", []*store.Store{a, b})
+	if got.NumberOfItems != 2 || got.ItemListElement[0].Position != 1 || got.ItemListElement[1].Position != 2 {
+		t.Fatalf("ItemList = %+v", got)
+	}
+	if got.ItemListElement[1].URL != "https://jinwoowide.com/store/yeoksam-jjeomo-b" {
+		t.Errorf("itemListElement[1].url = %s", got.ItemListElement[1].URL)
+	}
+}
+
+func TestJSONLDEscapesScript(t *testing.T) {
+	evil := "</script><script>alert(1)</script>"
+	s := jsonLDStore(t, evil, evil, store.Won(150000))
+	out := string(jsonLD(ldSite(), ldBreadcrumbs(s.Path(), []*Breadcrumb{{Name: s.Title}}), ldStore(s)))
+	if strings.Contains(strings.ToLower(out), "</script") || strings.Contains(out, "<") {
+		t.Fatalf("script 태그가 escape되지 않았습니다: %s", out)
+	}
+	// escape된 값은 그대로 다시 읽을 수 있어야 함
+	g := &struct {
+		Graph []map[string]any `json:"@graph"`
+	}{}
+	if err := json.Unmarshal([]byte(out), g); err != nil {
+		t.Fatal(err)
+	}
+	business := g.Graph[2]
+	if business["name"] != evil || business["address"].(map[string]any)["streetAddress"] != "역삼동 "+evil {
+		t.Errorf("name = %v, address = %v", business["name"], business["address"])
+	}
+}
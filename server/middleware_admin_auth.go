@@ -0,0 +1,113 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/csrf"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+	"github.com/gofiber/fiber/v2/middleware/session"
+	"github.com/jeonghoikun/jinwoowide.com/user"
+)
+
+// 관리자 로그인 세션. 메모리에 저장되므로 서버를 재시작하면 다시 로그인해야 함
+var adminSessions = session.New(session.Config{
+	Expiration:     12 * time.Hour,
+	KeyLookup:      "cookie:admin_session",
+	CookiePath:     "/admin",
+	CookieSecure:   true,
+	CookieHTTPOnly: true,
+	CookieSameSite: "Lax",
+})
+
+// adminCSRF: /admin의 POST 요청은 폼의 _csrf 값이 쿠키의 토큰과 같아야 함. 토큰은 템플릿에서 .CSRF
+func adminCSRF() fiber.Handler {
+	return csrf.New(csrf.Config{
+		KeyLookup:      "form:_csrf",
+		CookieName:     "admin_csrf",
+		CookiePath:     "/admin",
+		CookieSecure:   true,
+		CookieHTTPOnly: true,
+		CookieSameSite: "Strict",
+		Expiration:     12 * time.Hour,
+		ContextKey:     "csrf",
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			return c.Status(http.StatusForbidden).SendString("요청이 만료되었습니다. 페이지를 새로고침 후 다시 시도하세요")
+		},
+	})
+}
+
+// bindCSRF: adminCSRF 다음에 사용
+func bindCSRF(c *fiber.Ctx) error {
+	if err := c.Bind(fiber.Map{"CSRF": c.Locals("csrf")}); err != nil {
+		return c.Status(http.StatusInternalServerError).SendString(err.Error())
+	}
+	return c.Next()
+}
+
+// loginLimiter: IP와 아이디당 로그인 실패 10분에 5회까지.
+// 프록시 뒤에서는 모든 요청의 IP가 같아서 IP만으로 세면 한 사람의 실패로 모두 로그인할 수 없게 됨
+func loginLimiter(onLimit fiber.Handler) fiber.Handler {
+	return limiter.New(limiter.Config{
+		Max:        5,
+		Expiration: 10 * time.Minute,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return c.IP() + "|" + strings.TrimSpace(c.FormValue("name"))
+		},
+		SkipSuccessfulRequests: true,
+		LimitReached:           onLimit,
+	})
+}
+
+// startSession: name으로 로그인. 로그인 전 세션 ID를 재사용하지 않도록 새로 만들고 로그인 시각을 남김
+func startSession(c *fiber.Ctx, name string) error {
+	sess, err := adminSessions.Get(c)
+	if err != nil {
+		return err
+	}
+	if err := sess.Regenerate(); err != nil {
+		return err
+	}
+	sess.Set("user", name)
+	sess.Set("created", time.Now().UnixNano())
+	return sess.Save()
+}
+
+// requireLogin: 로그인한 계정을 Locals("user")와 템플릿의 .User로.
+// 로그인하지 않았거나 삭제된 계정, 로그인한 뒤 비밀번호나 권한이 바뀐 계정이면 로그인 페이지로
+func requireLogin(c *fiber.Ctx) error {
+	sess, err := adminSessions.Get(c)
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).SendString(err.Error())
+	}
+	name, _ := sess.Get("user").(string)
+	created, _ := sess.Get("created").(int64)
+	u, has := user.Get(name)
+	if !has || !u.IsSessionValid(time.Unix(0, created)) {
+		if err := sess.Destroy(); err != nil {
+			return c.Status(http.StatusInternalServerError).SendString(err.Error())
+		}
+		return c.Redirect("/admin/login", http.StatusSeeOther)
+	}
+	c.Locals("user", u)
+	if err := c.Bind(fiber.Map{"User": u}); err != nil {
+		return c.Status(http.StatusInternalServerError).SendString(err.Error())
+	}
+	return c.Next()
+}
+
+// requireAdmin: requireLogin 다음에 사용. 계정 관리 권한이 없으면 403
+func requireAdmin(c *fiber.Ctx) error {
+	if u := currentUser(c); u == nil || !u.IsAdmin() {
+		return c.Status(http.StatusForbidden).SendString("권한이 없습니다")
+	}
+	return c.Next()
+}
+
+// currentUser: requireLogin에서 확인한 계정
+func currentUser(c *fiber.Ctx) *user.User {
+	u, _ := c.Locals("user").(*user.User)
+	return u
+}
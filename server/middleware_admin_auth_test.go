@@ -0,0 +1,119 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jeonghoikun/jinwoowide.com/user"
+)
+
+// inTempDir: 계정 파일(data/user)을 임시 디렉토리에 쓰도록
+func inTempDir(t *testing.T) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func TestRequireLoginAfterCredentialsChange(t *testing.T) {
+	inTempDir(t)
+	if err := user.Init(); err != nil {
+		t.Fatal(err)
+	}
+	if err := user.Add("tester", "password1234", user.ROLE_EDITOR); err != nil {
+		t.Fatal(err)
+	}
+	app := fiber.New()
+	app.Post("/admin/start", func(c *fiber.Ctx) error {
+		if err := startSession(c, "tester"); err != nil {
+			return err
+		}
+		return c.SendStatus(http.StatusOK)
+	})
+	app.Get("/admin/check", requireLogin, func(c *fiber.Ctx) error { return c.SendStatus(http.StatusOK) })
+
+	login := func() string {
+		res, err := app.Test(httptest.NewRequest(http.MethodPost, "/admin/start", nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, cookie := range res.Cookies() {
+			if cookie.Name == "admin_session" {
+				return cookie.Value
+			}
+		}
+		t.Fatal("admin_session 쿠키가 없습니다")
+		return ""
+	}
+	check := func(step, session string, want int) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/admin/check", nil)
+		req.AddCookie(&http.Cookie{Name: "admin_session", Value: session})
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.StatusCode != want {
+			t.Errorf("%s: status = %d, want %d", step, res.StatusCode, want)
+		}
+	}
+
+	session := login()
+	check("로그인", session, http.StatusOK)
+	if err := user.SetRole("tester", user.ROLE_EDITOR); err != nil {
+		t.Fatal(err)
+	}
+	check("같은 권한으로 저장", session, http.StatusOK)
+	if err := user.SetPassword("tester", "password5678"); err != nil {
+		t.Fatal(err)
+	}
+	check("비밀번호 변경 전 세션", session, http.StatusSeeOther)
+	session = login()
+	check("비밀번호 변경 후 로그인", session, http.StatusOK)
+	if err := user.SetRole("tester", user.ROLE_ADMIN); err != nil {
+		t.Fatal(err)
+	}
+	check("권한 변경 전 세션", session, http.StatusSeeOther)
+	check("빈 세션", "", http.StatusSeeOther)
+}
+
+func TestLoginLimiterKey(t *testing.T) {
+	app := fiber.New()
+	app.Post("/admin/login", loginLimiter(func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusTooManyRequests)
+	}), func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusUnauthorized)
+	})
+	post := func(name string) int {
+		body := url.Values{"name": {name}, "password": {"wrong"}}.Encode()
+		req := httptest.NewRequest(http.MethodPost, "/admin/login", strings.NewReader(body))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationForm)
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.StatusCode
+	}
+	for i := 0; i < 5; i++ {
+		if got := post("alice"); got != http.StatusUnauthorized {
+			t.Fatalf("%d번째 실패: status = %d, want 401", i+1, got)
+		}
+	}
+	if got := post("alice"); got != http.StatusTooManyRequests {
+		t.Errorf("6번째 실패: status = %d, want 429", got)
+	}
+	// 같은 IP(프록시)라도 다른 아이디는 따로 셈
+	if got := post("bob"); got != http.StatusUnauthorized {
+		t.Errorf("다른 아이디: status = %d, want 401", got)
+	}
+}
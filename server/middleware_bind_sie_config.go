@@ -9,11 +9,15 @@ import (
 )
 
 func bindSiteConfig(c *fiber.Ctx) error {
+	// 요청 처리중에 Catalog가 교체되어도 같은 Catalog를 보도록 고정
+	catalog := store.Current()
+	c.Locals("catalog", catalog)
 	m := fiber.Map{
 		"Site": fiber.Map{
 			"Config": site.Config,
 			"Store": fiber.Map{
-				"Categories": store.ListAllCategories(),
+				"Categories": catalog.ListAllCategories(),
+				"Regions":    catalog.ListSiRegions(),
 			},
 		},
 	}
@@ -22,3 +26,11 @@ func bindSiteConfig(c *fiber.Ctx) error {
 	}
 	return c.Next()
 }
+
+// catalogOf: bindSiteConfig에서 고정한 Catalog
+func catalogOf(c *fiber.Ctx) *store.Catalog {
+	if catalog, ok := c.Locals("catalog").(*store.Catalog); ok {
+		return catalog
+	}
+	return store.Current()
+}
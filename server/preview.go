@@ -0,0 +1,98 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 미리보기 링크 서명 키. 저장소에 올리지 않음(.gitignore). 없으면 처음 사용할때 만듬
+const previewKeyFile = "data/secret/preview.key"
+
+// PREVIEW_TTL: 미리보기 링크 유효기간
+const PREVIEW_TTL = 7 * 24 * time.Hour
+
+var (
+	previewKeyOnce sync.Once
+	previewKey     []byte
+	previewKeyErr  error
+)
+
+func loadPreviewKey() ([]byte, error) {
+	previewKeyOnce.Do(func() {
+		b, err := os.ReadFile(previewKeyFile)
+		if err == nil {
+			previewKey, previewKeyErr = hex.DecodeString(strings.TrimSpace(string(b)))
+			return
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			previewKeyErr = err
+			return
+		}
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			previewKeyErr = err
+			return
+		}
+		if err := os.MkdirAll(filepath.Dir(previewKeyFile), 0700); err != nil {
+			previewKeyErr = err
+			return
+		}
+		if err := os.WriteFile(previewKeyFile, []byte(hex.EncodeToString(key)+"\n"), 0600); err != nil {
+			previewKeyErr = err
+			return
+		}
+		previewKey = key
+	})
+	return previewKey, previewKeyErr
+}
+
+func previewSignature(key []byte, slug string, exp int64) string {
+	mac := hmac.New(sha256.New, key)
+	fmt.Fprintf(mac, "%s\n%d", slug, exp)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// previewToken: slug 업소의 미리보기 토큰. ex) 1710147600.{서명}
+func previewToken(slug string, exp time.Time) (string, error) {
+	key, err := loadPreviewKey()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d.%s", exp.Unix(), previewSignature(key, slug, exp.Unix())), nil
+}
+
+// previewURL: 공개하지 않은 업소를 로그인 없이 볼 수 있는 링크. now부터 PREVIEW_TTL 동안 유효
+func previewURL(path, slug string, now time.Time) (string, error) {
+	token, err := previewToken(slug, now.Add(PREVIEW_TTL))
+	if err != nil {
+		return "", err
+	}
+	return path + "?preview=" + token, nil
+}
+
+// validPreview: token이 slug 업소의 만료되지 않은 미리보기 토큰인지
+func validPreview(slug, token string, now time.Time) bool {
+	i := strings.IndexByte(token, '.')
+	if i < 0 {
+		return false
+	}
+	exp, err := strconv.ParseInt(token[:i], 10, 64)
+	if err != nil || now.Unix() > exp {
+		return false
+	}
+	key, err := loadPreviewKey()
+	if err != nil {
+		return false
+	}
+	return hmac.Equal([]byte(token[i+1:]), []byte(previewSignature(key, slug, exp)))
+}
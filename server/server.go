@@ -3,12 +3,13 @@ package server
 import (
 	"fmt"
 	"math/rand"
+	"strings"
 	"time"
 
-	"github.com/dustin/go-humanize"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/compress"
 	"github.com/gofiber/template/html/v2"
+	"github.com/jeonghoikun/jinwoowide.com/media"
 	"github.com/jeonghoikun/jinwoowide.com/site"
 )
 
@@ -35,19 +36,6 @@ func (*engineFunc) randFileNumber() int {
 	return rand.Intn(max-min) + min
 }
 
-func (*engineFunc) commaByPrice(prices ...int) string {
-	var n = 0
-	for _, p := range prices {
-		n += p
-	}
-	if n == 0 {
-		return "문의"
-	}
-	return humanize.Comma(int64(n))
-}
-
-func (*engineFunc) multiply(a, b int) int { return a * b }
-
 func (*engineFunc) listNumbers(ns ...int) []int {
 	list := []int{}
 	for _, n := range ns {
@@ -56,6 +44,20 @@ func (*engineFunc) listNumbers(ns ...int) []int {
 	return list
 }
 
+// imageURL: /static 아래 이미지 src를 너비 w로 줄인 /image 경로로. ex) /static/img/a.png -> /image/480/img/a.png
+func (*engineFunc) imageURL(src string, w int) string {
+	return fmt.Sprintf("/image/%d/%s", w, strings.TrimPrefix(src, "/static/"))
+}
+
+// srcset: ex) /image/320/img/a.png 320w, /image/640/img/a.png 640w
+func (ef *engineFunc) srcset(src string, widths ...int) string {
+	ss := make([]string, 0, len(widths))
+	for _, w := range widths {
+		ss = append(ss, fmt.Sprintf("%s %dw", ef.imageURL(src, w), w))
+	}
+	return strings.Join(ss, ", ")
+}
+
 func engine() *html.Engine {
 	e := html.New("./views", ".html")
 	e.Reload(true)
@@ -63,9 +65,9 @@ func engine() *html.Engine {
 	e.AddFunc("Time", ef.time)
 	e.AddFunc("WithHost", ef.withHost)
 	e.AddFunc("RandFileNumber", ef.randFileNumber)
-	e.AddFunc("CommaByPrice", ef.commaByPrice)
-	e.AddFunc("Multiply", ef.multiply)
 	e.AddFunc("ListNumbers", ef.listNumbers)
+	e.AddFunc("ImageURL", ef.imageURL)
+	e.AddFunc("Srcset", ef.srcset)
 	return e
 }
 
@@ -75,6 +77,8 @@ func New(portNumber uint32) *Server {
 		AppName:      site.Config.Domain,
 		ServerHeader: site.Config.Domain,
 		Views:        engine(),
+		// 관리자 이미지 업로드. 파일당 media.MAX_UPLOAD_SIZE, 한번에 MAX_UPLOAD_FILES장
+		BodyLimit: media.MAX_UPLOAD_SIZE*MAX_UPLOAD_FILES + 1<<20,
 	})
 	return &Server{port: &p, app: app}
 }
@@ -93,6 +97,9 @@ func (s *Server) middlewares() {
 func (s *Server) routes() {
 	handleCategory(s.app.Group("/category"))
 	handleStore(s.app.Group("/store"))
+	handleAPI(s.app.Group("/api"))
+	handleImage(s.app.Group("/image"))
+	handleAdmin(s.app.Group("/admin"))
 	handleIndex(s.app.Group("/"))
 }
 
@@ -108,6 +115,12 @@ type Author struct {
 	ProfilePath string
 }
 
+// Breadcrumb: Path가 비어있으면 현재 페이지
+type Breadcrumb struct {
+	Name string
+	Path string
+}
+
 type PageConfig struct {
 	Path          string
 	Author        *Author
@@ -118,4 +131,6 @@ type PageConfig struct {
 	DatePublished time.Time
 	DateModified  time.Time
 	ThumbnailPath string
+	// Schema: 페이지 종류별 구조화 데이터(BreadcrumbList, ItemList, LocalBusiness 등). WebSite, WebPage는 JSONLD에서 추가
+	Schema []any
 }
@@ -0,0 +1,223 @@
+package server
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jeonghoikun/jinwoowide.com/site"
+	"github.com/jeonghoikun/jinwoowide.com/store"
+)
+
+// 사이트맵 프로토콜 제한. 파일 하나에 URL 50,000개, 50MB
+const (
+	SITEMAP_MAX_URLS  = 50000
+	SITEMAP_MAX_BYTES = 50 << 20
+)
+
+const (
+	sitemapXmlns      = "http://www.sitemaps.org/schemas/sitemap/0.9"
+	sitemapImageXmlns = "http://www.google.com/schemas/sitemap-image/1.1"
+)
+
+type sitemapImage struct {
+	Loc string `xml:"image:loc"`
+}
+
+type sitemapURL struct {
+	Loc     string          `xml:"loc"`
+	LastMod string          `xml:"lastmod,omitempty"`
+	Images  []*sitemapImage `xml:"image:image"`
+
+	lastMod time.Time
+}
+
+type sitemapURLSet struct {
+	XMLName    xml.Name      `xml:"urlset"`
+	Xmlns      string        `xml:"xmlns,attr"`
+	XmlnsImage string        `xml:"xmlns:image,attr"`
+	URLs       []*sitemapURL `xml:"url"`
+}
+
+type sitemapEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name        `xml:"sitemapindex"`
+	Xmlns    string          `xml:"xmlns,attr"`
+	Sitemaps []*sitemapEntry `xml:"sitemap"`
+}
+
+// absURL: 사이트 경로의 전체 URL. 경로의 각 부분(한글 지역, 업종 이름 등)은 escape됨
+func absURL(path string) string {
+	parts := strings.Split(path, "/")
+	for i, p := range parts {
+		parts[i] = url.PathEscape(p)
+	}
+	return fmt.Sprintf("https://%s%s", site.Config.Domain, strings.Join(parts, "/"))
+}
+
+func newSitemapURL(path string, lastMod time.Time) *sitemapURL {
+	u := &sitemapURL{Loc: absURL(path), lastMod: lastMod}
+	if !lastMod.IsZero() {
+		u.LastMod = lastMod.Format(time.RFC3339)
+	}
+	return u
+}
+
+// sitemapGroup: 하위 사이트맵 하나의 URL 목록. name은 파일 이름에 사용. ex) stores -> /sitemap-stores.xml
+type sitemapGroup struct {
+	name string
+	urls []*sitemapURL
+}
+
+// sitemapGroups: 공개중인 업소, 업종 목록, 지역 허브 페이지
+func sitemapGroups(catalog *store.Catalog) []*sitemapGroup {
+	stores := &sitemapGroup{name: "stores"}
+	for _, s := range catalog.ListAllStores() {
+		u := newSitemapURL(s.Path(), s.DateModified)
+		for _, img := range s.Images() {
+			u.Images = append(u.Images, &sitemapImage{Loc: absURL(img)})
+		}
+		stores.urls = append(stores.urls, u)
+	}
+	categories := &sitemapGroup{name: "categories"}
+	for _, category := range catalog.ListAllCategories() {
+		categories.urls = append(categories.urls, newSitemapURL(category.Path(), category.DateModified()))
+	}
+	regions := &sitemapGroup{name: "regions", urls: []*sitemapURL{newSitemapURL("/", site.Config.DateModified)}}
+	var walk func(list []*store.Region)
+	walk = func(list []*store.Region) {
+		for _, r := range list {
+			if len(r.Stores) == 0 {
+				continue
+			}
+			regions.urls = append(regions.urls, newSitemapURL(r.Path(), r.DateModified()))
+			walk(r.Children)
+		}
+	}
+	walk(catalog.ListRegions())
+	return []*sitemapGroup{regions, categories, stores}
+}
+
+func marshalSitemap(v any) ([]byte, error) {
+	b, err := xml.MarshalIndent(v, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), b...), nil
+}
+
+// urlsetSize: URL 없는 urlset 파일 크기
+var urlsetSize = func() int {
+	b, _ := marshalSitemap(&sitemapURLSet{Xmlns: sitemapXmlns, XmlnsImage: sitemapImageXmlns})
+	return len(b)
+}()
+
+// split: 프로토콜 제한을 넘지 않도록 urls를 나눔
+func split(urls []*sitemapURL) ([][]*sitemapURL, error) {
+	var chunks [][]*sitemapURL
+	var chunk []*sitemapURL
+	size := urlsetSize
+	for _, u := range urls {
+		b, err := xml.MarshalIndent(u, "\t", "\t")
+		if err != nil {
+			return nil, err
+		}
+		n := len(b) + 1
+		if len(chunk) > 0 && (len(chunk) >= SITEMAP_MAX_URLS || size+n > SITEMAP_MAX_BYTES) {
+			chunks = append(chunks, chunk)
+			chunk, size = nil, urlsetSize
+		}
+		chunk = append(chunk, u)
+		size += n
+	}
+	return append(chunks, chunk), nil
+}
+
+func maxLastMod(urls []*sitemapURL) time.Time {
+	var t time.Time
+	for _, u := range urls {
+		if u.lastMod.After(t) {
+			t = u.lastMod
+		}
+	}
+	return t
+}
+
+// sitemapFiles: 파일 이름별 사이트맵 XML. 모두 한 파일에 들어가면 sitemap.xml 하나,
+// 넘으면 sitemap.xml은 인덱스이고 sitemap-{group}.xml, sitemap-{group}-{n}.xml 하위 사이트맵
+func sitemapFiles(catalog *store.Catalog) (map[string][]byte, error) {
+	groups := sitemapGroups(catalog)
+	var all []*sitemapURL
+	for _, g := range groups {
+		all = append(all, g.urls...)
+	}
+	chunks, err := split(all)
+	if err != nil {
+		return nil, err
+	}
+	if len(chunks) == 1 {
+		b, err := marshalSitemap(&sitemapURLSet{Xmlns: sitemapXmlns, XmlnsImage: sitemapImageXmlns, URLs: all})
+		if err != nil {
+			return nil, err
+		}
+		return map[string][]byte{"sitemap.xml": b}, nil
+	}
+	files := map[string][]byte{}
+	index := &sitemapIndex{Xmlns: sitemapXmlns}
+	for _, g := range groups {
+		chunks, err := split(g.urls)
+		if err != nil {
+			return nil, err
+		}
+		for i, chunk := range chunks {
+			name := fmt.Sprintf("sitemap-%s.xml", g.name)
+			if i > 0 {
+				name = fmt.Sprintf("sitemap-%s-%d.xml", g.name, i+1)
+			}
+			b, err := marshalSitemap(&sitemapURLSet{Xmlns: sitemapXmlns, XmlnsImage: sitemapImageXmlns, URLs: chunk})
+			if err != nil {
+				return nil, err
+			}
+			files[name] = b
+			entry := &sitemapEntry{Loc: absURL("/" + name)}
+			if t := maxLastMod(chunk); !t.IsZero() {
+				entry.LastMod = t.Format(time.RFC3339)
+			}
+			index.Sitemaps = append(index.Sitemaps, entry)
+		}
+	}
+	b, err := marshalSitemap(index)
+	if err != nil {
+		return nil, err
+	}
+	files["sitemap.xml"] = b
+	return files, nil
+}
+
+// sitemapCache: 마지막으로 만든 Catalog의 사이트맵. Catalog가 바뀌면 다시 만듬
+var sitemapCache struct {
+	mu      sync.Mutex
+	catalog *store.Catalog
+	files   map[string][]byte
+}
+
+// sitemapFile: catalog의 사이트맵 파일 name. 없는 파일이면 nil
+func sitemapFile(catalog *store.Catalog, name string) ([]byte, error) {
+	sitemapCache.mu.Lock()
+	defer sitemapCache.mu.Unlock()
+	if sitemapCache.catalog != catalog {
+		files, err := sitemapFiles(catalog)
+		if err != nil {
+			return nil, err
+		}
+		sitemapCache.catalog, sitemapCache.files = catalog, files
+	}
+	return sitemapCache.files[name], nil
+}
@@ -0,0 +1,275 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jeonghoikun/jinwoowide.com/media"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/util"
+)
+
+// 소개글 디렉토리. views/store/{Do}/{Si}/{Dong}/{Type}/{Title}.md 또는 .html
+const articleDir = "views/store"
+
+// GALLERY_SCHEME: Markdown 이미지 주소로 갤러리 이미지를 가리킴.
+// ex) ![홀 내부](gallery:1), ![홀 내부](gallery:3f2a9c1b0d4e), ![홀 내부](gallery:hall)
+const GALLERY_SCHEME = "gallery:"
+
+// Article: 업소 소개글. Markdown 파일이 있으면 Markdown, 없으면 기존 HTML 파일.
+// 변수([[title]] 등)는 Catalog를 만들때의 업소 값으로 바뀌므로 메뉴, 영업시간 변경이 적용되면 같이 바뀜
+type Article struct {
+	// File: 소개글 파일 경로
+	File string
+	// Markdown: false면 HTML 파일
+	Markdown bool
+	// Title: front matter의 title. 있으면 페이지 제목 대신 사용
+	Title string
+	// HTML: 변수를 바꾼 소개글. Markdown은 HTML로 변환하고 허용된 태그만 남김
+	HTML template.HTML
+	// body: front matter를 뺀 원문. 콘텐츠 점검에 사용
+	body []byte
+}
+
+// frontMatter: Markdown 파일 맨 앞 --- 사이의 설정
+//
+//	---
+//	title: 역삼 831 쩜오 소개
+//	images:
+//	  hall: 3f2a9c1b0d4e
+//	---
+type frontMatter struct {
+	Title string
+	// Images: 본문에서 gallery:{key}로 쓰는 이미지 별칭. value는 갤러리 이미지 Name
+	Images map[string]string
+}
+
+// splitFrontMatter: b를 front matter와 본문으로 나눔. front matter가 없으면 빈 값
+func splitFrontMatter(b []byte) (*frontMatter, []byte, error) {
+	fm := &frontMatter{Images: map[string]string{}}
+	b = bytes.TrimPrefix(b, []byte("\xef\xbb\xbf"))
+	text := strings.ReplaceAll(string(b), "\r\n", "\n")
+	if !strings.HasPrefix(text, "---\n") {
+		return fm, []byte(text), nil
+	}
+	head, body, ok := strings.Cut(text[len("---\n"):], "\n---")
+	if !ok {
+		return nil, nil, fmt.Errorf("front matter: 닫는 --- 가 없습니다")
+	}
+	// 닫는 --- 줄의 나머지
+	if i := strings.IndexByte(body, '\n'); i >= 0 {
+		body = body[i+1:]
+	} else {
+		body = ""
+	}
+	inImages := false
+	for i, line := range strings.Split(head, "\n") {
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, nil, fmt.Errorf("front matter %d번째 줄: key: value 형식이어야 합니다", i+1)
+		}
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+		// images 아래 들여쓴 줄
+		if inImages && (strings.HasPrefix(key, " ") || strings.HasPrefix(key, "\t")) {
+			fm.Images[strings.TrimSpace(key)] = value
+			continue
+		}
+		inImages = false
+		switch key {
+		case "title":
+			fm.Title = value
+		case "images":
+			inImages = true
+		default:
+			return nil, nil, fmt.Errorf("front matter %d번째 줄: %q 알 수 없는 항목입니다 (title, images)", i+1, key)
+		}
+	}
+	return fm, []byte(body), nil
+}
+
+// articlePolicy: Markdown 변환 결과에서 허용하는 태그. 본문에 직접 쓴 HTML도 이 정책으로 걸러짐
+var articlePolicy = func() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowElements("picture", "figure", "figcaption")
+	p.AllowAttrs("type").Matching(bluemonday.Paragraph).OnElements("source")
+	p.AllowAttrs("srcset", "sizes").OnElements("source", "img")
+	p.AllowAttrs("loading", "decoding").OnElements("img")
+	p.AllowAttrs("class").Matching(bluemonday.SpaceSeparatedTokens).OnElements("figure")
+	return p
+}()
+
+// galleryRenderer: gallery: 주소의 Markdown 이미지를 갤러리 이미지의 <picture>로 변환
+type galleryRenderer struct {
+	store  *Store
+	images map[string]string
+	errs   []error
+}
+
+func (r *galleryRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindImage, r.renderImage)
+}
+
+// find: gallery: 뒤의 값으로 갤러리 이미지를 찾음. 1부터 시작하는 순서, front matter 별칭, 이미지 Name 순서로
+func (r *galleryRenderer) find(ref string) (*media.Image, bool) {
+	gallery := r.store.Gallery
+	if n, err := strconv.Atoi(ref); err == nil {
+		if n < 1 || n > len(gallery) {
+			return nil, false
+		}
+		return gallery[n-1], true
+	}
+	if name, has := r.images[ref]; has {
+		ref = name
+	}
+	for _, img := range gallery {
+		if img.Name == ref {
+			return img, true
+		}
+	}
+	return nil, false
+}
+
+// nodeText: 이미지 alt로 사용할 텍스트
+func nodeText(source []byte, n ast.Node) []byte {
+	var b bytes.Buffer
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		if t, ok := c.(*ast.Text); ok {
+			b.Write(t.Segment.Value(source))
+			continue
+		}
+		b.Write(nodeText(source, c))
+	}
+	return b.Bytes()
+}
+
+func (r *galleryRenderer) renderImage(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*ast.Image)
+	alt := template.HTMLEscapeString(string(nodeText(source, n)))
+	dest := string(n.Destination)
+	if !strings.HasPrefix(dest, GALLERY_SCHEME) {
+		fmt.Fprintf(w, `<img src="%s" alt="%s" loading="lazy">`, template.HTMLEscapeString(dest), alt)
+		return ast.WalkSkipChildren, nil
+	}
+	ref := strings.TrimPrefix(dest, GALLERY_SCHEME)
+	img, has := r.find(ref)
+	if !has {
+		r.errs = append(r.errs, fmt.Errorf("%s: 갤러리에 없는 이미지입니다", dest))
+		return ast.WalkSkipChildren, nil
+	}
+	if alt == "" {
+		alt = template.HTMLEscapeString(img.Alt)
+	}
+	const sizes = "(min-width: 640px) 640px, 100vw"
+	dir := r.store.ImageDir()
+	w.WriteString("<picture>")
+	for _, s := range img.Sources(dir) {
+		fmt.Fprintf(w, `<source type="%s" srcset="%s" sizes="%s">`,
+			template.HTMLEscapeString(s.Type), template.HTMLEscapeString(s.Srcset), sizes)
+	}
+	fmt.Fprintf(w, `<img src="%s" srcset="%s" sizes="%s" width="%d" height="%d" loading="lazy" decoding="async" alt="%s">`,
+		template.HTMLEscapeString(img.Src(dir)), template.HTMLEscapeString(img.Srcset(dir, img.Ext)), sizes,
+		img.Width, img.Height, alt)
+	w.WriteString("</picture>")
+	return ast.WalkSkipChildren, nil
+}
+
+// renderMarkdown: 본문을 HTML로 변환하고 허용된 태그만 남김. gallery: 이미지는 s의 갤러리에서 찾음
+func renderMarkdown(s *Store, fm *frontMatter, body []byte) (template.HTML, error) {
+	gr := &galleryRenderer{store: s, images: fm.Images}
+	md := goldmark.New(
+		goldmark.WithExtensions(extension.Table, extension.Strikethrough),
+		goldmark.WithRendererOptions(
+			// 기존 HTML 소개글을 옮겨 붙여도 되도록 본문의 HTML을 그대로 출력하고 articlePolicy로 거름
+			html.WithUnsafe(),
+			renderer.WithNodeRenderers(util.Prioritized(gr, 100)),
+		),
+	)
+	var b bytes.Buffer
+	if err := md.Convert(body, &b); err != nil {
+		return "", err
+	}
+	if len(gr.errs) > 0 {
+		return "", errorList(gr.errs)
+	}
+	return template.HTML(articlePolicy.SanitizeBytes(b.Bytes())), nil
+}
+
+// articleFile: 위치, 업종, 상호의 소개글 파일 경로. ext는 .md 또는 .html
+func articleFile(l *Location, storeType, title, ext string) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s/%s%s", articleDir, l.Do, l.Si, l.Dong, storeType, title, ext)
+}
+
+// articlePath: 소개글 파일 경로. ext는 .md 또는 .html
+func (s *Store) articlePath(ext string) string { return articleFile(s.Location, s.Type, s.Title, ext) }
+
+// loadArticle: Markdown 파일이 있으면 변환해서, 없으면 HTML 파일을 소개글로 사용.
+// 둘 다 변수와 {a|b|c} 문구를 먼저 바꿈
+func loadArticle(s *Store) (*Article, error) {
+	md := s.articlePath(".md")
+	b, err := os.ReadFile(md)
+	if os.IsNotExist(err) {
+		a := &Article{File: s.articlePath(".html")}
+		if a.body, err = os.ReadFile(a.File); err != nil {
+			return a, nil
+		}
+		// 직접 작성한 HTML 파일이므로 거르지 않음
+		text, err := expandArticle(s, string(a.body), true)
+		if err != nil {
+			return nil, &recordError{Path: a.File, Err: err}
+		}
+		a.HTML = template.HTML(text)
+		return a, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	fm, body, err := splitFrontMatter(b)
+	if err != nil {
+		return nil, &recordError{Path: md, Err: err}
+	}
+	a := &Article{File: md, Markdown: true, body: body}
+	// title은 템플릿에서 escape됨
+	if a.Title, err = expandArticle(s, fm.Title, false); err != nil {
+		return nil, &recordError{Path: md, Err: fmt.Errorf("title: %s", err)}
+	}
+	text, err := expandArticle(s, string(body), true)
+	if err != nil {
+		return nil, &recordError{Path: md, Err: err}
+	}
+	if a.HTML, err = renderMarkdown(s, fm, []byte(text)); err != nil {
+		return nil, &recordError{Path: md, Err: err}
+	}
+	return a, nil
+}
+
+// loadArticles: 모든 업소의 소개글을 읽음. Catalog를 만들때 한번만 변환하고 요청마다 다시 변환하지 않음
+func loadArticles(stores []*Store) error {
+	var errs errorList
+	for _, s := range stores {
+		a, err := loadArticle(s)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		s.Article = a
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
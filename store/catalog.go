@@ -0,0 +1,307 @@
+package store
+
+import (
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Catalog: 한 시점의 업소 목록과 조회용 인덱스. 만들어진 뒤에는 변경하지 않음.
+// 조회 함수가 반환하는 slice는 공유되므로 수정하면 안됨
+type Catalog struct {
+	// all: 공개하지 않은 업소까지 모든 업소. DatePublished 오름차순
+	all []*Store
+	// allBySlug: 공개하지 않은 업소까지. 현재 Slug와 FormerSlugs 모두 포함
+	allBySlug map[string]*Store
+	// 아래는 공개중인 업소만
+	// stores: DatePublished 오름차순
+	stores []*Store
+	// 아래 인덱스의 목록은 모두 DatePublished 내림차순
+	byKey map[storeKey]*Store
+	// byFormerPath: FormerPaths. 현재 위치, 업종, 상호인 byKey가 우선
+	byFormerPath map[storeKey]*Store
+	// bySlug: 현재 Slug와 FormerSlugs 모두 포함
+	bySlug     map[string]*Store
+	byDoSiType map[doSiKey][]*Store
+	byDoSiDong map[doSiKey][]*Store
+	byType     map[string][]*Store
+	// grid: 근처 업소 검색용. 좌표가 있고 폐업하지 않은 업소의 칸별 목록
+	grid map[gridCell][]*Store
+	// regions: data/region.json의 도 목록. 파일 순서 그대로
+	regions  []*Region
+	byRegion map[string]*Region
+	// siRegions: 업소가 있는 시 지역. 파일 순서 그대로
+	siRegions []*Region
+	// refreshAt: 아직 적용되지 않은 변경 이력의 적용일, 공개 시각 중 가장 빠른 시각. 이 시각이 지나면 다시 만들어야 함
+	refreshAt time.Time
+}
+
+type storeKey struct{ do, si, dong, storeType, title string }
+
+// legacyPath: 이전 주소 형식 /store/{Do}/{Si}/{Dong}/{Type}/{Title}의 경로 부분. ex) 서울/강남구/역삼동/쩜오/831
+func (k storeKey) legacyPath() string {
+	return strings.Join([]string{k.do, k.si, k.dong, k.storeType, k.title}, "/")
+}
+
+// parseLegacyPath: legacyPath의 반대. 빈 항목이 있으면 false
+func parseLegacyPath(p string) (storeKey, bool) {
+	parts := strings.Split(p, "/")
+	if len(parts) != 5 {
+		return storeKey{}, false
+	}
+	for _, part := range parts {
+		if strings.TrimSpace(part) == "" {
+			return storeKey{}, false
+		}
+	}
+	return storeKey{parts[0], parts[1], parts[2], parts[3], parts[4]}, true
+}
+
+// keyOf: s의 현재 위치, 업종, 상호
+func keyOf(s *Store) storeKey {
+	l := s.Location
+	return storeKey{l.Do, l.Si, l.Dong, s.Type, s.Title}
+}
+
+// doSiKey: 도, 시와 업종 또는 동
+type doSiKey struct{ do, si, name string }
+
+// current: 현재 서비스중인 Catalog. Reload 시 통째로 교체됨
+var current atomic.Pointer[Catalog]
+
+func init() { current.Store(buildIndexes([]*Store{}, nil)) }
+
+// Current: 현재 Catalog. 한 요청 안에서는 같은 Catalog를 사용해야 일관된 결과를 얻음
+func Current() *Catalog { return current.Load() }
+
+func (c *Catalog) Get(do, si, dong, storeType, title string) (o *Store, has bool) {
+	o, has = c.byKey[storeKey{do, si, dong, storeType, title}]
+	return o, has
+}
+
+// GetByFormerPath: 위치, 업종, 상호를 바꾸기 전의 이전 주소로 찾음. 지금 그 주소를 쓰는 업소가 있으면 그 업소
+func (c *Catalog) GetByFormerPath(do, si, dong, storeType, title string) (o *Store, has bool) {
+	k := storeKey{do, si, dong, storeType, title}
+	if o, has = c.byKey[k]; has {
+		return o, has
+	}
+	o, has = c.byFormerPath[k]
+	return o, has
+}
+
+// GetBySlug: 이전 Slug로도 찾을 수 있음. s.Slug != slug 이면 이전 Slug
+func (c *Catalog) GetBySlug(slug string) (o *Store, has bool) {
+	o, has = c.bySlug[slug]
+	return o, has
+}
+
+func (c *Catalog) ListAllStores() []*Store { return c.stores }
+
+// GetBySlugIncludingHidden: 공개하지 않은 업소도 찾음. 관리자 화면, 미리보기에서 사용
+func (c *Catalog) GetBySlugIncludingHidden(slug string) (o *Store, has bool) {
+	o, has = c.allBySlug[slug]
+	return o, has
+}
+
+// ListAllStoresIncludingHidden: 공개하지 않은 업소까지 모든 업소. DatePublished 오름차순
+func (c *Catalog) ListAllStoresIncludingHidden() []*Store { return c.all }
+
+func (c *Catalog) ListStoresByDoSiAndStoreType(do, si, storeType string) []*Store {
+	return c.byDoSiType[doSiKey{do, si, storeType}]
+}
+
+func (c *Catalog) ListStoresByDoSiAndDong(do, si, dong string) []*Store {
+	return c.byDoSiDong[doSiKey{do, si, dong}]
+}
+
+func (c *Catalog) ListStoresByStoreType(storeType string) []*Store { return c.byType[storeType] }
+
+// Category: 한 지역의 업종 하나
+type Category struct {
+	Region *Region
+	// Name: 업종. ex) 하이퍼블릭
+	Name   string
+	Stores []*Store
+}
+
+// Path: 업종 목록 페이지 경로. ex) /category/서울/강남구/하이퍼블릭
+// 업종 목록 페이지는 시 단위만 있으므로 도, 동 지역의 Category는 빈 문자열
+func (c *Category) Path() string {
+	if c.Region.Level != REGION_LEVEL_SI {
+		return ""
+	}
+	return c.Region.Path() + "/" + c.Name
+}
+
+// DateModified: 이 업종 업소들의 가장 최근 수정일
+func (c *Category) DateModified() time.Time {
+	var t time.Time
+	for _, s := range c.Stores {
+		if s.DateModified.After(t) {
+			t = s.DateModified
+		}
+	}
+	return t
+}
+
+// ListAllCategories: 시 지역별 업종 목록. 지역은 파일 순서, 업종은 이름순
+func (c *Catalog) ListAllCategories() []*Category {
+	list := []*Category{}
+	for _, r := range c.siRegions {
+		list = append(list, r.Categories...)
+	}
+	return list
+}
+
+// ListRegions: 도 목록. data/region.json 순서
+func (c *Catalog) ListRegions() []*Region { return c.regions }
+
+// ListSiRegions: 업소가 있는 시 지역. 메뉴, 카테고리 링크를 지역별로 만들때 사용
+func (c *Catalog) ListSiRegions() []*Region { return c.siRegions }
+
+// Region: 도, 시, 동 이름으로 지역을 찾음. ex) Region("서울", "강남구")
+func (c *Catalog) Region(names ...string) (r *Region, has bool) {
+	r, has = c.byRegion[regionKey(names...)]
+	return r, has
+}
+
+// Stale: 적용일이 지난 변경 이력이 있어서 다시 만들어야 하는지
+func (c *Catalog) Stale(now time.Time) bool { return !c.refreshAt.IsZero() && !now.Before(c.refreshAt) }
+
+func Get(do, si, dong, storeType, title string) (o *Store, has bool) {
+	return Current().Get(do, si, dong, storeType, title)
+}
+
+func GetBySlug(slug string) (o *Store, has bool) { return Current().GetBySlug(slug) }
+
+func ListAllStores() []*Store { return Current().ListAllStores() }
+
+func ListStoresByDoSiAndStoreType(do, si, storeType string) []*Store {
+	return Current().ListStoresByDoSiAndStoreType(do, si, storeType)
+}
+
+func ListStoresByDoSiAndDong(do, si, dong string) []*Store {
+	return Current().ListStoresByDoSiAndDong(do, si, dong)
+}
+
+func ListStoresByStoreType(storeType string) []*Store {
+	return Current().ListStoresByStoreType(storeType)
+}
+
+func ListAllCategories() []*Category { return Current().ListAllCategories() }
+
+func ListRegions() []*Region { return Current().ListRegions() }
+
+func ListSiRegions() []*Region { return Current().ListSiRegions() }
+
+func GetRegion(names ...string) (r *Region, has bool) { return Current().Region(names...) }
+
+// newCatalog: 읽어온 업소 목록을 정렬하고 자동 입력 항목을 채움. 업소의 Region은 연결되어 있어야 함
+func newCatalog(stores []*Store, regions []*Region) (*Catalog, error) {
+	now := time.Now()
+	var next time.Time
+	for _, s := range stores {
+		if t := s.applyHistory(now); !t.IsZero() && (next.IsZero() || t.Before(next)) {
+			next = t
+		}
+		s.public = s.isPublicAt(now)
+		// 공개 예정인 업소는 공개 시각에 다시 만들어서 공개
+		if t := s.PublishAt; !s.public && t.After(now) && (next.IsZero() || t.Before(next)) &&
+			(s.PublishStatus == PUBLISH_SCHEDULED || s.PublishStatus == PUBLISH_PUBLISHED) {
+			next = t
+		}
+	}
+	sortStores(stores)
+
+	setStoreKeywords(stores)
+	setPhoneNumbers(stores)
+
+	if err := createViewsDirectories(stores); err != nil {
+		return nil, err
+	}
+	if err := createHTMLFiles(stores); err != nil {
+		return nil, err
+	}
+	if err := createStaticImgDirectories(stores); err != nil {
+		return nil, err
+	}
+	if err := loadArticles(stores); err != nil {
+		return nil, err
+	}
+	c := buildIndexes(stores, regions)
+	c.refreshAt = next
+	return c, nil
+}
+
+func buildIndexes(stores []*Store, regions []*Region) *Catalog {
+	c := &Catalog{
+		all:          stores,
+		allBySlug:    make(map[string]*Store, len(stores)),
+		stores:       make([]*Store, 0, len(stores)),
+		byKey:        make(map[storeKey]*Store, len(stores)),
+		byFormerPath: map[storeKey]*Store{},
+		bySlug:       make(map[string]*Store, len(stores)),
+		byDoSiType:   map[doSiKey][]*Store{},
+		byDoSiDong:   map[doSiKey][]*Store{},
+		byType:       map[string][]*Store{},
+		regions:      regions,
+		byRegion:     map[string]*Region{},
+		siRegions:    []*Region{},
+	}
+	for _, s := range stores {
+		c.allBySlug[s.Slug] = s
+		for _, slug := range s.FormerSlugs {
+			c.allBySlug[slug] = s
+		}
+		if s.public {
+			c.stores = append(c.stores, s)
+		}
+	}
+	// stores가 오름차순이므로 뒤에서부터 넣으면 내림차순 목록이 됨
+	for i := len(c.stores) - 1; i >= 0; i-- {
+		s := c.stores[i]
+		l := s.Location
+		c.byKey[storeKey{l.Do, l.Si, l.Dong, s.Type, s.Title}] = s
+		c.bySlug[s.Slug] = s
+		for _, slug := range s.FormerSlugs {
+			c.bySlug[slug] = s
+		}
+		for _, p := range s.FormerPaths {
+			if k, ok := parseLegacyPath(p); ok {
+				c.byFormerPath[k] = s
+			}
+		}
+		k := doSiKey{l.Do, l.Si, s.Type}
+		c.byDoSiType[k] = append(c.byDoSiType[k], s)
+		k = doSiKey{l.Do, l.Si, l.Dong}
+		c.byDoSiDong[k] = append(c.byDoSiDong[k], s)
+		c.byType[s.Type] = append(c.byType[s.Type], s)
+		for r := s.Region; r != nil; r = r.Parent {
+			r.Stores = append(r.Stores, s)
+		}
+	}
+	c.grid = buildGrid(c.stores)
+	c.indexRegions(regions)
+	return c
+}
+
+// indexRegions: 지역별 업종 목록을 만들고 이름으로 찾을 수 있게 등록
+func (c *Catalog) indexRegions(list []*Region) {
+	for _, r := range list {
+		c.byRegion[regionKey(r.Names()...)] = r
+		byType := map[string][]*Store{}
+		for _, s := range r.Stores {
+			byType[s.Type] = append(byType[s.Type], s)
+		}
+		r.Categories = make([]*Category, 0, len(byType))
+		for name, stores := range byType {
+			r.Categories = append(r.Categories, &Category{Region: r, Name: name, Stores: stores})
+		}
+		sort.Slice(r.Categories, func(i, j int) bool { return r.Categories[i].Name < r.Categories[j].Name })
+		if r.Level == REGION_LEVEL_SI && len(r.Stores) > 0 {
+			c.siRegions = append(c.siRegions, r)
+		}
+		c.indexRegions(r.Children)
+	}
+}
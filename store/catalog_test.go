@@ -0,0 +1,157 @@
+package store
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// 벤치마크용 지역, 업소 수. 도 4 x 시 10 x 동 12 = 480개 동에 업소 4,800개
+const (
+	benchDoCount     = 4
+	benchSiPerDo     = 10
+	benchDongPerSi   = 12
+	benchStoresPerDo = 1200
+)
+
+// benchRegions: 벤치마크용 지역 목록
+func benchRegions(tb testing.TB) []*Region {
+	var regions []*Region
+	for d := 0; d < benchDoCount; d++ {
+		do := &Region{Name: fmt.Sprintf("도%d", d), Short: fmt.Sprintf("도%d", d)}
+		for s := 0; s < benchSiPerDo; s++ {
+			si := &Region{Name: fmt.Sprintf("시%d-%d", d, s), Short: fmt.Sprintf("시%d-%d", d, s)}
+			for n := 0; n < benchDongPerSi; n++ {
+				si.Children = append(si.Children, &Region{Name: fmt.Sprintf("동%d-%d-%d", d, s, n), Short: fmt.Sprintf("동%d", n)})
+			}
+			do.Children = append(do.Children, si)
+		}
+		regions = append(regions, do)
+	}
+	if errs := linkRegions("regions", nil, regions); len(errs) > 0 {
+		tb.Fatal(errorList(errs))
+	}
+	return regions
+}
+
+// benchStores: regions의 동마다 고르게 나눈 업소. DatePublished 오름차순
+func benchStores(tb testing.TB, regions []*Region) []*Store {
+	var stores []*Store
+	published := time.Date(2023, 1, 1, 0, 0, 0, 0, time.Local)
+	i := 0
+	for _, do := range regions {
+		for n := 0; n < benchStoresPerDo; n++ {
+			si := do.Children[n%len(do.Children)]
+			dong := si.Children[(n/len(do.Children))%len(si.Children)]
+			stores = append(stores, &Store{
+				Location:      &Location{Do: do.Name, Si: si.Name, Dong: dong.Name, Address: fmt.Sprint(n)},
+				Type:          storeTypes[n%len(storeTypes)],
+				Title:         fmt.Sprintf("업소%d", i),
+				Slug:          fmt.Sprintf("store-%d", i),
+				Active:        &Active{},
+				DatePublished: published.AddDate(0, 0, i),
+				public:        true,
+			})
+			i++
+		}
+	}
+	if err := assignRegions(stores, regions); err != nil {
+		tb.Fatal(err)
+	}
+	return stores
+}
+
+// resetRegions: buildIndexes가 채우는 지역의 업소, 업종 목록을 비움
+func resetRegions(list []*Region) {
+	for _, r := range list {
+		r.Stores, r.Categories = nil, nil
+		resetRegions(r.Children)
+	}
+}
+
+func benchCatalog(b *testing.B) (*Catalog, []*Store) {
+	regions := benchRegions(b)
+	stores := benchStores(b, regions)
+	return buildIndexes(stores, regions), stores
+}
+
+func BenchmarkGet(b *testing.B) {
+	c, stores := benchCatalog(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s := stores[i%len(stores)]
+		l := s.Location
+		if _, has := c.Get(l.Do, l.Si, l.Dong, s.Type, s.Title); !has {
+			b.Fatalf("%s: 업소를 찾을 수 없습니다", s.Slug)
+		}
+	}
+}
+
+func BenchmarkListStoresByDoSiAndStoreType(b *testing.B) {
+	c, stores := benchCatalog(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s := stores[i%len(stores)]
+		if len(c.ListStoresByDoSiAndStoreType(s.Location.Do, s.Location.Si, s.Type)) == 0 {
+			b.Fatalf("%s: 목록이 비어있습니다", s.Slug)
+		}
+	}
+}
+
+func BenchmarkListAllCategories(b *testing.B) {
+	c, _ := benchCatalog(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if len(c.ListAllCategories()) == 0 {
+			b.Fatal("업종 목록이 비어있습니다")
+		}
+	}
+}
+
+func BenchmarkBuildIndexes(b *testing.B) {
+	regions := benchRegions(b)
+	stores := benchStores(b, regions)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		resetRegions(regions)
+		b.StartTimer()
+		if c := buildIndexes(stores, regions); len(c.ListAllStores()) != len(stores) {
+			b.Fatalf("업소 %d개 중 %d개만 인덱스에 있습니다", len(stores), len(c.ListAllStores()))
+		}
+	}
+}
+
+func TestGetByFormerPath(t *testing.T) {
+	moved := &Store{
+		Location: &Location{Do: "서울", Si: "강남구", Dong: "논현동"}, Type: STORE_TYPE_DOT5, Title: "새이름",
+		Slug: "moved", Active: &Active{}, public: true,
+		FormerPaths: []string{"서울/강남구/역삼동/쩜오/옛이름", "서울/강남구/역삼동/쩜오/현재"},
+	}
+	current := &Store{
+		Location: &Location{Do: "서울", Si: "강남구", Dong: "역삼동"}, Type: STORE_TYPE_DOT5, Title: "현재",
+		Slug: "current", Active: &Active{}, public: true,
+	}
+	c := buildIndexes([]*Store{moved, current}, nil)
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"옛이름", "moved"},
+		// 지금 그 주소를 쓰는 업소가 우선
+		{"현재", "current"},
+		{"없음", ""},
+	}
+	for _, tt := range tests {
+		got, has := c.GetByFormerPath("서울", "강남구", "역삼동", STORE_TYPE_DOT5, tt.title)
+		if tt.want == "" {
+			if has {
+				t.Errorf("%s: GetByFormerPath() = %s, want 없음", tt.title, got.Slug)
+			}
+			continue
+		}
+		if !has || got.Slug != tt.want {
+			t.Errorf("%s: GetByFormerPath() = %v, want %s", tt.title, got, tt.want)
+		}
+	}
+}
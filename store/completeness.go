@@ -0,0 +1,161 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ARTICLE_PLACEHOLDER: 서버 시작시 자동 생성되는 소개글 파일의 내용
+const ARTICLE_PLACEHOLDER = "write me!"
+
+// 갤러리를 올리기 전의 업소 이미지 1.png ~ 4.png
+const legacyImageCount = 4
+
+// Issue: 업소 페이지에 빠졌거나 채우지 않은 콘텐츠 하나
+type Issue struct {
+	// Field: ex) thumbnail, images, article, location.geo, menu.tc.price
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Report: 업소 하나의 콘텐츠 점검 결과
+type Report struct {
+	Slug   string   `json:"slug"`
+	Title  string   `json:"title"`
+	Type   string   `json:"type"`
+	Path   string   `json:"path"`
+	File   string   `json:"file"`
+	Issues []*Issue `json:"issues"`
+}
+
+// Complete: 빠진 콘텐츠가 없는지
+func (r *Report) Complete() bool { return len(r.Issues) == 0 }
+
+// imageFile: 업소 이미지 디렉토리의 name 파일 경로
+func (s *Store) imageFile(name string) string {
+	return filepath.Join(strings.TrimPrefix(s.ImageDir(), "/"), name)
+}
+
+func nonEmptyFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir() && info.Size() > 0
+}
+
+func checkPrice(field string, p Price) *Issue {
+	switch {
+	case !p.Known:
+		return &Issue{Field: field, Message: "가격이 문의(미입력)입니다"}
+	case p.Amount == 0:
+		return &Issue{Field: field, Message: "0원입니다. 무료가 아니면 금액 또는 문의(null)로 입력하세요"}
+	}
+	return nil
+}
+
+func checkCharge(field string, c *Charge) *Issue {
+	if c == nil {
+		return &Issue{Field: field, Message: "값이 없습니다"}
+	}
+	return checkPrice(field+".price", c.Price)
+}
+
+func checkMenu(m *Menu) []*Issue {
+	var issues []*Issue
+	if m == nil {
+		return []*Issue{{Field: "menu", Message: "메뉴가 없습니다"}}
+	}
+	for part := 1; part <= 2; part++ {
+		bottles := m.Bottles(part)
+		if len(bottles) == 0 {
+			issues = append(issues, &Issue{Field: fmt.Sprintf("menu.part%d", part), Message: "주대가 없습니다"})
+		}
+		for i, b := range bottles {
+			if issue := checkPrice(fmt.Sprintf("menu.part%d[%d].price", part, i), b.Price); issue != nil {
+				issues = append(issues, issue)
+			}
+		}
+	}
+	if issue := checkCharge("menu.tc", m.TC); issue != nil {
+		issues = append(issues, issue)
+	}
+	if issue := checkCharge("menu.rt", m.RT); issue != nil {
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// Images: 업소 페이지에 표시되는 이미지 URL. 대표 이미지, 갤러리(없으면 파일이 있는 기존 번호 이미지)
+func (s *Store) Images() []string {
+	list := []string{s.ThumbnailPath()}
+	dir := s.ImageDir()
+	for _, img := range s.Gallery {
+		list = append(list, img.Src(dir))
+	}
+	if len(s.Gallery) == 0 {
+		for i := 1; i <= legacyImageCount; i++ {
+			if name := fmt.Sprintf("%d.png", i); nonEmptyFile(s.imageFile(name)) {
+				list = append(list, dir+"/"+name)
+			}
+		}
+	}
+	return list
+}
+
+// Check: 업소 페이지에 필요한 콘텐츠 점검. 대표 이미지, 업소 이미지, 소개글, 지도, 메뉴 가격
+func (s *Store) Check() *Report {
+	r := &Report{Slug: s.Slug, Title: s.Title, Type: s.Type, Path: s.Path(), File: dataPath(s), Issues: []*Issue{}}
+	add := func(field, message string) { r.Issues = append(r.Issues, &Issue{Field: field, Message: message}) }
+
+	if !nonEmptyFile(s.imageFile("thumbnail.png")) {
+		add("thumbnail", s.imageFile("thumbnail.png")+" 파일이 없습니다")
+	}
+	// 갤러리가 없으면 기존 번호 이미지를 사용
+	if len(s.Gallery) == 0 {
+		var missing []string
+		for i := 1; i <= legacyImageCount; i++ {
+			if name := fmt.Sprintf("%d.png", i); !nonEmptyFile(s.imageFile(name)) {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) > 0 {
+			add("images", fmt.Sprintf("갤러리 이미지가 없고 %s 파일이 없습니다", strings.Join(missing, ", ")))
+		}
+	} else {
+		for _, img := range s.Gallery {
+			if !nonEmptyFile(s.imageFile(img.File(img.Widths[0], img.Ext))) {
+				add("gallery", fmt.Sprintf("%s 이미지 파일이 없습니다", img.Name))
+			}
+		}
+	}
+	switch b := s.Article.body; {
+	case b == nil:
+		add("article", s.Article.File+" 파일이 없습니다")
+	case len(bytes.TrimSpace(b)) == 0:
+		add("article", "소개글이 비어있습니다")
+	case string(bytes.TrimSpace(b)) == ARTICLE_PLACEHOLDER:
+		add("article", "소개글이 자동 생성된 내용("+ARTICLE_PLACEHOLDER+") 그대로입니다")
+	}
+	if s.Location.LatLng() == nil {
+		add("location.geo", "좌표가 없습니다")
+	}
+	if strings.TrimSpace(s.Location.RoadAddress) == "" {
+		add("location.roadAddress", "도로명주소가 없습니다")
+	}
+	r.Issues = append(r.Issues, checkMenu(s.Menu)...)
+	return r
+}
+
+// Reports: 공개하지 않은 업소까지 모든 업소의 점검 결과. incompleteOnly면 빠진 콘텐츠가 있는 업소만
+func (c *Catalog) Reports(incompleteOnly bool) []*Report {
+	list := []*Report{}
+	for _, s := range c.all {
+		r := s.Check()
+		if incompleteOnly && r.Complete() {
+			continue
+		}
+		list = append(list, r)
+	}
+	return list
+}
@@ -0,0 +1,146 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Geo: WGS84 좌표
+type Geo struct {
+	// Lat: 위도. ex) 37.4954171
+	Lat float64 `json:"lat"`
+	// Lng: 경도. ex) 127.0266819
+	Lng float64 `json:"lng"`
+}
+
+func (g *Geo) String() string {
+	return strconv.FormatFloat(g.Lat, 'f', -1, 64) + "," + strconv.FormatFloat(g.Lng, 'f', -1, 64)
+}
+
+func validateGeo(g *Geo) []error {
+	var errs []error
+	if g.Lat < -90 || g.Lat > 90 {
+		errs = append(errs, fmt.Errorf("location.geo.lat: -90 ~ 90 사이여야 합니다 (%v)", g.Lat))
+	}
+	if g.Lng < -180 || g.Lng > 180 {
+		errs = append(errs, fmt.Errorf("location.geo.lng: -180 ~ 180 사이여야 합니다 (%v)", g.Lng))
+	}
+	if g.Lat == 0 && g.Lng == 0 {
+		errs = append(errs, fmt.Errorf("location.geo: 0,0 은 올바른 좌표가 아닙니다"))
+	}
+	return errs
+}
+
+// 구글맵 embed URL의 pb 파라미터에 들어있는 지도 중심 좌표. !2d{경도}!3d{위도}
+var mapSrcPattern = regexp.MustCompile(`!2d(-?[0-9]+(?:\.[0-9]+)?)!3d(-?[0-9]+(?:\.[0-9]+)?)`)
+
+// GeoFromMapSrc: 구글맵 iframe src의 좌표. 좌표가 없으면 false
+func GeoFromMapSrc(src string) (*Geo, bool) {
+	m := mapSrcPattern.FindStringSubmatch(src)
+	if m == nil {
+		return nil, false
+	}
+	lng, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return nil, false
+	}
+	lat, err := strconv.ParseFloat(m[2], 64)
+	if err != nil {
+		return nil, false
+	}
+	g := &Geo{Lat: lat, Lng: lng}
+	if len(validateGeo(g)) > 0 {
+		return nil, false
+	}
+	return g, true
+}
+
+// LatLng: 업소 좌표. geo가 없으면 아직 옮기지 않은 googleMapSrc에서 찾음. 둘 다 없으면 nil
+func (l *Location) LatLng() *Geo {
+	if l.Geo != nil {
+		return l.Geo
+	}
+	if g, ok := GeoFromMapSrc(l.GoogleMapSrc); ok {
+		return g
+	}
+	return nil
+}
+
+// MapSrc: 좌표로 만든 구글맵 iframe src. 좌표가 없으면 빈 문자열
+func (l *Location) MapSrc() string {
+	g := l.LatLng()
+	if g == nil {
+		return ""
+	}
+	q := url.Values{"q": {g.String()}, "z": {"17"}, "hl": {"ko"}, "output": {"embed"}}
+	return "https://maps.google.com/maps?" + q.Encode()
+}
+
+// GeoMigration: MigrateGeo 결과
+type GeoMigration struct {
+	// Migrated: googleMapSrc에서 geo로 옮긴 파일
+	Migrated []string
+	// Failed: googleMapSrc에서 좌표를 찾지 못한 파일. googleMapSrc는 그대로 둠
+	Failed []string
+}
+
+// MigrateGeo: data 파일의 location.googleMapSrc에서 좌표를 찾아 location.geo로 옮기고 googleMapSrc는 지움.
+// 이미 geo가 있으면 googleMapSrc만 지움. dryRun이면 파일을 쓰지 않음
+func MigrateGeo(dryRun bool) (*GeoMigration, error) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	m := &GeoMigration{}
+	err := filepath.WalkDir(dataDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".json") {
+			return nil
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		r := &Record{}
+		if err := json.Unmarshal(b, r); err != nil {
+			return &recordError{Path: path, Err: err}
+		}
+		l := r.Location
+		if l == nil || l.GoogleMapSrc == "" {
+			return nil
+		}
+		if l.Geo == nil {
+			g, ok := GeoFromMapSrc(l.GoogleMapSrc)
+			if !ok {
+				m.Failed = append(m.Failed, path)
+				return nil
+			}
+			l.Geo = g
+		}
+		l.GoogleMapSrc = ""
+		m.Migrated = append(m.Migrated, path)
+		if dryRun {
+			return nil
+		}
+		out, err := encodeRecord(r)
+		if err != nil {
+			return &recordError{Path: path, Err: err}
+		}
+		return writeFile(path, out)
+	})
+	if err != nil {
+		return m, err
+	}
+	if dryRun || len(m.Migrated) == 0 {
+		return m, nil
+	}
+	return m, reload()
+}
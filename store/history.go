@@ -0,0 +1,94 @@
+package store
+
+import (
+	"time"
+)
+
+// Revision: data 파일의 history 항목. Effective 날짜부터 적용되는 변경 사항이며 nil인 항목은 변경 없음
+type Revision struct {
+	// Effective: 적용일. ex) 2024-06-04
+	Effective string  `json:"effective"`
+	Note      string  `json:"note,omitempty"`
+	Menu      *Menu   `json:"menu,omitempty"`
+	Hour      *Hour   `json:"hour,omitempty"`
+	Active    *Active `json:"active,omitempty"`
+}
+
+// Version: 한 시점의 메뉴, 영업시간, 영업상태 전체
+type Version struct {
+	Effective time.Time `json:"effective"`
+	Note      string    `json:"note,omitempty"`
+	// Changes: 이전 버전과 달라진 항목. ex) 메뉴, 영업시간, 영업상태
+	Changes []string `json:"changes"`
+	Menu    *Menu    `json:"menu"`
+	Hour    *Hour    `json:"hour"`
+	Active  *Active  `json:"active"`
+}
+
+func newBaseVersion(s *Store) *Version {
+	return &Version{
+		Effective: s.DatePublished,
+		Note:      "등록",
+		Changes:   []string{},
+		Menu:      s.Menu,
+		Hour:      s.Hour,
+		Active:    s.Active,
+	}
+}
+
+// applyHistory: now 기준으로 적용일이 지난 Revision을 순서대로 반영해서
+// History, Menu, Hour, Active, DateModified를 채움. 아직 적용되지 않은 가장 빠른 적용일을 반환
+func (s *Store) applyHistory(now time.Time) (next time.Time) {
+	v := s.base
+	s.History = []*Version{v}
+	s.DateModified = s.DatePublished
+	for _, r := range s.revisions {
+		effective, _ := parseDate("effective", r.Effective)
+		if effective.After(now) {
+			if next.IsZero() || effective.Before(next) {
+				next = effective
+			}
+			continue
+		}
+		v = &Version{
+			Effective: effective,
+			Note:      r.Note,
+			Changes:   []string{},
+			Menu:      v.Menu,
+			Hour:      v.Hour,
+			Active:    v.Active,
+		}
+		if r.Menu != nil {
+			v.Menu = r.Menu
+			v.Changes = append(v.Changes, "메뉴")
+		}
+		if r.Hour != nil {
+			v.Hour = r.Hour
+			v.Changes = append(v.Changes, "영업시간")
+		}
+		if r.Active != nil {
+			v.Active = r.Active
+			v.Changes = append(v.Changes, "영업상태")
+		}
+		s.History = append(s.History, v)
+		if effective.After(s.DateModified) {
+			s.DateModified = effective
+		}
+	}
+	s.Menu = v.Menu
+	s.Hour = v.Hour
+	s.Active = v.Active
+	return next
+}
+
+// ListHistory: 최신 버전부터. 바뀐 항목이 없는 버전은 제외.
+// ex) 이전 dateModified를 옮긴 Revision. DateModified에는 반영됨
+func (s *Store) ListHistory() []*Version {
+	list := make([]*Version, 0, len(s.History))
+	for i := len(s.History) - 1; i >= 0; i-- {
+		if v := s.History[i]; i == 0 || len(v.Changes) > 0 {
+			list = append(list, v)
+		}
+	}
+	return list
+}
@@ -0,0 +1,39 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestApplyHistory(t *testing.T) {
+	published := time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local)
+	menu := &Menu{Part1: []*Bottle{{Name: "양주 세트", Price: Won(150000)}}}
+	raised := &Menu{Part1: []*Bottle{{Name: "양주 세트", Price: Won(170000)}}}
+	s := &Store{DatePublished: published, Menu: menu, Hour: &Hour{}, Active: &Active{}}
+	s.base = newBaseVersion(s)
+	s.revisions = []*Revision{
+		{Effective: "2024-06-01", Note: "주대 인상", Menu: raised},
+		// 이전 dateModified를 옮긴 Revision. 바뀐 항목 없음
+		{Effective: "2024-09-13", Note: "정보 수정"},
+		{Effective: "2024-12-01", Note: "폐업", Active: &Active{IsPermanentClosed: true, Reason: "정상폐업"}},
+	}
+	next := s.applyHistory(time.Date(2024, 10, 1, 0, 0, 0, 0, time.Local))
+	if want := time.Date(2024, 12, 1, 0, 0, 0, 0, time.Local); !next.Equal(want) {
+		t.Errorf("next = %v, want %v", next, want)
+	}
+	if s.Menu != raised || s.Active.IsPermanentClosed {
+		t.Errorf("Menu, Active = %v, %v, want 인상된 메뉴, 영업중", s.Menu, s.Active)
+	}
+	// 바뀐 항목이 없는 Revision도 수정일에는 반영
+	if want := time.Date(2024, 9, 13, 0, 0, 0, 0, time.Local); !s.DateModified.Equal(want) {
+		t.Errorf("DateModified = %v, want %v", s.DateModified, want)
+	}
+	notes := []string{}
+	for _, v := range s.ListHistory() {
+		notes = append(notes, v.Note)
+	}
+	if want := []string{"주대 인상", "등록"}; !reflect.DeepEqual(notes, want) {
+		t.Errorf("ListHistory() = %v, want %v", notes, want)
+	}
+}
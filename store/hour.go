@@ -0,0 +1,317 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Clock: 자정부터의 분. 0(00:00) ~ 1440(24:00)
+type Clock int
+
+func (c Clock) String() string { return fmt.Sprintf("%02d:%02d", int(c)/60, int(c)%60) }
+
+// parseClock: "18:00" 형식의 시간을 Clock으로 변환. 24:00 까지 허용
+func parseClock(v string) (Clock, error) {
+	hh, mm, ok := strings.Cut(v, ":")
+	if !ok || len(hh) != 2 || len(mm) != 2 {
+		return 0, fmt.Errorf("%q 형식이 올바르지 않습니다 (ex. 18:00)", v)
+	}
+	h, err := strconv.Atoi(hh)
+	if err != nil {
+		return 0, fmt.Errorf("%q 형식이 올바르지 않습니다 (ex. 18:00)", v)
+	}
+	m, err := strconv.Atoi(mm)
+	if err != nil {
+		return 0, fmt.Errorf("%q 형식이 올바르지 않습니다 (ex. 18:00)", v)
+	}
+	if h < 0 || h > 24 || m < 0 || m > 59 || (h == 24 && m != 0) {
+		return 0, fmt.Errorf("%q 존재하지 않는 시간입니다", v)
+	}
+	return Clock(h*60 + m), nil
+}
+
+// Span: 하루의 영업 구간. Closed가 Open보다 이르거나 같으면 다음날 Closed까지 영업
+type Span struct {
+	Open   Clock
+	Closed Clock
+}
+
+// Overnight: 자정을 넘겨서 영업하는지
+func (s Span) Overnight() bool { return s.Closed <= s.Open }
+
+// contains: 시작한 날 기준 m분이 영업 구간인지
+func (s Span) contains(m Clock) bool {
+	if s.Overnight() {
+		return m >= s.Open
+	}
+	return m >= s.Open && m < s.Closed
+}
+
+// containsNextDay: 전날 시작한 구간이 다음날 m분까지 이어지는지
+func (s Span) containsNextDay(m Clock) bool { return s.Overnight() && m < s.Closed }
+
+type TimeType struct {
+	// Has: 유무
+	Has bool `json:"has"`
+	// Open: 오픈시간. ex) 18:00
+	Open string `json:"open,omitempty"`
+	// Closed: 마감시간. ex) 00:00. 오픈시간보다 이르면 다음날 마감
+	Closed string `json:"closed,omitempty"`
+}
+
+func (t *TimeType) span() (sp Span, has bool, err error) {
+	if t == nil || !t.Has {
+		return Span{}, false, nil
+	}
+	var errs errorList
+	open, err := parseClock(t.Open)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("open: %s", err))
+	}
+	closed, err := parseClock(t.Closed)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("closed: %s", err))
+	}
+	if len(errs) > 0 {
+		return Span{}, false, errs
+	}
+	return Span{Open: open, Closed: closed}, true, nil
+}
+
+// Weekday: JSON에서는 "sun", "mon", ... "sat"
+type Weekday time.Weekday
+
+var weekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+var weekdayLabels = []string{"일", "월", "화", "수", "목", "금", "토"}
+
+// Label: ex) 월
+func (d Weekday) Label() string { return weekdayLabels[d] }
+
+// String: ex) mon
+func (d Weekday) String() string { return weekdayNames[d] }
+
+func (d Weekday) MarshalJSON() ([]byte, error) { return json.Marshal(d.String()) }
+
+// ParseWeekday: "sun", "mon", ... "sat"
+func ParseWeekday(v string) (Weekday, error) {
+	for i, name := range weekdayNames {
+		if name == v {
+			return Weekday(i), nil
+		}
+	}
+	return 0, fmt.Errorf("%q 요일은 sun, mon, tue, wed, thu, fri, sat 중 하나여야 합니다", v)
+}
+
+func (d *Weekday) UnmarshalJSON(b []byte) error {
+	var v string
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	w, err := ParseWeekday(v)
+	if err != nil {
+		return err
+	}
+	*d = w
+	return nil
+}
+
+// WeeklyHour: 특정 요일에만 적용되는 영업시간. Part1, Part2 둘다 Has=false면 그 요일은 정기휴무
+type WeeklyHour struct {
+	Days  []Weekday `json:"days"`
+	Part1 *TimeType `json:"part1"`
+	Part2 *TimeType `json:"part2"`
+}
+
+// Closure: 임시휴업 기간. From ~ To 날짜 모두 포함
+type Closure struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Reason string `json:"reason"`
+}
+
+type Hour struct {
+	// Part1: 1부. Weekly에 없는 요일에 적용
+	Part1 *TimeType `json:"part1"`
+	// Part2: 2부. Weekly에 없는 요일에 적용
+	Part2 *TimeType `json:"part2"`
+	// Weekly: 요일별 영업시간. ex) 일요일만 1부 영업
+	Weekly []*WeeklyHour `json:"weekly,omitempty"`
+	// Holidays: 휴무일. 그날 시작하는 영업이 없음. ex) 2024-09-17
+	Holidays []string `json:"holidays,omitempty"`
+	// Closures: 임시휴업 기간
+	Closures []*Closure `json:"closures,omitempty"`
+
+	// 아래는 compile에서 채워짐
+	days     [7][]Span
+	holidays map[string]bool
+	closures []*closureRange
+}
+
+// Part: n부의 영업시간
+func (h *Hour) Part(n int) *TimeType {
+	if n == 2 {
+		return h.Part2
+	}
+	return h.Part1
+}
+
+type closureRange struct {
+	from, to time.Time
+	reason   string
+}
+
+func spans(part1, part2 *TimeType) ([]Span, error) {
+	var errs errorList
+	list := []Span{}
+	for i, t := range []*TimeType{part1, part2} {
+		sp, has, err := t.span()
+		if err != nil {
+			errs = append(errs, &recordError{Path: fmt.Sprintf("part%d", i+1), Err: err})
+			continue
+		}
+		if has {
+			list = append(list, sp)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return list, nil
+}
+
+// compile: 문자열로 입력된 영업시간을 해석. 에러는 필드 이름과 함께 모두 모아서 반환
+func (h *Hour) compile() error {
+	var errs errorList
+	base, err := spans(h.Part1, h.Part2)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for d := range h.days {
+		h.days[d] = base
+	}
+	for i, w := range h.Weekly {
+		field := fmt.Sprintf("weekly[%d]", i)
+		if len(w.Days) == 0 {
+			errs = append(errs, fmt.Errorf("%s.days: 값이 없습니다", field))
+		}
+		list, err := spans(w.Part1, w.Part2)
+		if err != nil {
+			errs = append(errs, &recordError{Path: field, Err: err})
+			continue
+		}
+		for _, d := range w.Days {
+			h.days[d] = list
+		}
+	}
+	h.holidays = map[string]bool{}
+	for i, v := range h.Holidays {
+		t, err := parseDate(fmt.Sprintf("holidays[%d]", i), v)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		h.holidays[t.Format(dateLayout)] = true
+	}
+	h.closures = nil
+	for i, c := range h.Closures {
+		field := fmt.Sprintf("closures[%d]", i)
+		from, err := parseDate(field+".from", c.From)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		to, err := parseDate(field+".to", c.To)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if to.Before(from) {
+			errs = append(errs, fmt.Errorf("%s: to가 from보다 이릅니다", field))
+			continue
+		}
+		h.closures = append(h.closures, &closureRange{from: from, to: to.AddDate(0, 0, 1), reason: c.Reason})
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Week: 일요일부터 요일별 영업시간. Weekly가 적용된 값이고 휴무일, 임시휴업은 반영하지 않음
+func (h *Hour) Week() [7][]Span { return h.days }
+
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// closureAt: t가 임시휴업 기간이면 해당 기간
+func (h *Hour) closureAt(t time.Time) *closureRange {
+	for _, c := range h.closures {
+		if !t.Before(c.from) && t.Before(c.to) {
+			return c
+		}
+	}
+	return nil
+}
+
+// spansOn: day에 시작하는 영업 구간. 휴무일이면 없음
+func (h *Hour) spansOn(day time.Time) []Span {
+	if h.holidays[day.Format(dateLayout)] {
+		return nil
+	}
+	return h.days[day.Weekday()]
+}
+
+// IsOpenAt: t에 영업중인지. 시간은 Asia/Seoul(time.Local) 기준
+func (h *Hour) IsOpenAt(t time.Time) bool {
+	t = t.In(time.Local)
+	if h.closureAt(t) != nil {
+		return false
+	}
+	day := midnight(t)
+	m := Clock(t.Hour()*60 + t.Minute())
+	for _, sp := range h.spansOn(day) {
+		if sp.contains(m) {
+			return true
+		}
+	}
+	for _, sp := range h.spansOn(day.AddDate(0, 0, -1)) {
+		if sp.containsNextDay(m) {
+			return true
+		}
+	}
+	return false
+}
+
+// Status: 업소 페이지와 카드에 표시하는 영업 상태
+type Status struct {
+	// Open: 지금 영업중
+	Open bool
+	// Label: ex) 영업중, 영업종료, 임시휴업, 폐업(사유)
+	Label string
+}
+
+// StatusAt: 폐업, 임시휴업, 영업시간 순으로 t 시점의 상태를 계산
+func (s *Store) StatusAt(t time.Time) *Status {
+	if s.Active.IsPermanentClosed {
+		return &Status{Label: fmt.Sprintf("폐업(%s)", s.Active.Reason)}
+	}
+	if c := s.Hour.closureAt(t.In(time.Local)); c != nil {
+		if c.reason == "" {
+			return &Status{Label: "임시휴업"}
+		}
+		return &Status{Label: fmt.Sprintf("임시휴업(%s)", c.reason)}
+	}
+	if s.Hour.IsOpenAt(t) {
+		return &Status{Open: true, Label: "영업중"}
+	}
+	return &Status{Label: "영업종료"}
+}
+
+func (s *Store) StatusNow() *Status { return s.StatusAt(time.Now()) }
+
+func (s *Store) IsOpenAt(t time.Time) bool { return s.StatusAt(t).Open }
@@ -0,0 +1,114 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+// hourStore: 매일 18:00 ~ 05:00, 일요일만 20:00 ~ 24:00, 화요일 정기휴무,
+// 2024-03-14(목) 휴무일, 2024-03-21(목) ~ 2024-03-22(금) 임시휴업
+func hourStore(t *testing.T) *Store {
+	t.Helper()
+	s := &Store{
+		Active: &Active{},
+		Hour: &Hour{
+			Part1: &TimeType{Has: true, Open: "18:00", Closed: "05:00"},
+			Part2: &TimeType{Has: false},
+			Weekly: []*WeeklyHour{
+				{Days: []Weekday{Weekday(time.Sunday)}, Part1: &TimeType{Has: true, Open: "20:00", Closed: "24:00"}, Part2: &TimeType{Has: false}},
+				{Days: []Weekday{Weekday(time.Tuesday)}, Part1: &TimeType{Has: false}, Part2: &TimeType{Has: false}},
+			},
+			Holidays: []string{"2024-03-14"},
+			Closures: []*Closure{{From: "2024-03-21", To: "2024-03-22", Reason: "리모델링"}},
+		},
+	}
+	if err := s.Hour.compile(); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// at: 2024년 3월 day일 hh:mm. 2024-03-11은 월요일
+func at(day, hh, mm int) time.Time { return time.Date(2024, 3, day, hh, mm, 0, 0, time.Local) }
+
+func TestStatusAt(t *testing.T) {
+	s := hourStore(t)
+	tests := []struct {
+		name  string
+		t     time.Time
+		open  bool
+		label string
+	}{
+		{"월 오픈 전", at(11, 17, 59), false, "영업종료"},
+		{"월 오픈", at(11, 18, 0), true, "영업중"},
+		{"자정", at(12, 0, 0), true, "영업중"},
+		{"화 새벽 마감 전", at(12, 4, 59), true, "영업중"},
+		{"화 새벽 마감", at(12, 5, 0), false, "영업종료"},
+		{"화 정기휴무", at(12, 20, 0), false, "영업종료"},
+		{"정기휴무 다음날 새벽", at(13, 2, 0), false, "영업종료"},
+		{"휴무일 전날 시작한 영업", at(14, 2, 0), true, "영업중"},
+		{"휴무일 저녁", at(14, 20, 0), false, "영업종료"},
+		{"휴무일 다음날 새벽", at(15, 2, 0), false, "영업종료"},
+		{"토요일 영업이 일요일 새벽까지", at(17, 4, 0), true, "영업중"},
+		{"일 평소 오픈 시간", at(17, 18, 0), false, "영업종료"},
+		{"일 오픈", at(17, 20, 0), true, "영업중"},
+		{"일 24:00 마감 전", at(17, 23, 59), true, "영업중"},
+		{"일 24:00 마감", at(18, 0, 0), false, "영업종료"},
+		{"임시휴업 전날", at(20, 23, 0), true, "영업중"},
+		{"임시휴업 첫날 새벽", at(21, 2, 0), false, "임시휴업(리모델링)"},
+		{"임시휴업 마지막날", at(22, 20, 0), false, "임시휴업(리모델링)"},
+		{"임시휴업 다음날", at(23, 20, 0), true, "영업중"},
+	}
+	for _, tt := range tests {
+		got := s.StatusAt(tt.t)
+		if got.Open != tt.open || got.Label != tt.label {
+			t.Errorf("%s: StatusAt(%s) = {%v %s}, want {%v %s}", tt.name, tt.t.Format("01-02 Mon 15:04"), got.Open, got.Label, tt.open, tt.label)
+		}
+		if open := s.Hour.IsOpenAt(tt.t); open != tt.open {
+			t.Errorf("%s: IsOpenAt(%s) = %v, want %v", tt.name, tt.t.Format("01-02 Mon 15:04"), open, tt.open)
+		}
+	}
+}
+
+func TestStatusAtClosure(t *testing.T) {
+	s := hourStore(t)
+	s.Hour.Closures[0].Reason = ""
+	if err := s.Hour.compile(); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.StatusAt(at(21, 20, 0)); got.Open || got.Label != "임시휴업" {
+		t.Errorf("StatusAt() = {%v %s}, want {false 임시휴업}", got.Open, got.Label)
+	}
+}
+
+func TestStatusAtPermanentClosed(t *testing.T) {
+	s := hourStore(t)
+	s.Active = &Active{IsPermanentClosed: true, Reason: "정상폐업"}
+	// 영업시간이나 임시휴업과 관계없이 폐업
+	for _, tm := range []time.Time{at(11, 20, 0), at(21, 20, 0)} {
+		if got := s.StatusAt(tm); got.Open || got.Label != "폐업(정상폐업)" {
+			t.Errorf("StatusAt(%s) = {%v %s}, want {false 폐업(정상폐업)}", tm.Format("01-02 15:04"), got.Open, got.Label)
+		}
+		if s.IsOpenAt(tm) {
+			t.Errorf("IsOpenAt(%s) = true, want false", tm.Format("01-02 15:04"))
+		}
+	}
+}
+
+func TestHourCompileErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		h    *Hour
+	}{
+		{"형식", &Hour{Part1: &TimeType{Has: true, Open: "6:00", Closed: "05:00"}}},
+		{"없는 시간", &Hour{Part1: &TimeType{Has: true, Open: "24:30", Closed: "05:00"}}},
+		{"요일 없음", &Hour{Weekly: []*WeeklyHour{{Part1: &TimeType{Has: false}}}}},
+		{"휴무일", &Hour{Holidays: []string{"2024-02-30"}}},
+		{"임시휴업 순서", &Hour{Closures: []*Closure{{From: "2024-03-22", To: "2024-03-21"}}}},
+	}
+	for _, tt := range tests {
+		if err := tt.h.compile(); err == nil {
+			t.Errorf("%s: compile() 에러가 없습니다", tt.name)
+		}
+	}
+}
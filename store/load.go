@@ -0,0 +1,213 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jeonghoikun/jinwoowide.com/media"
+)
+
+// 업소 데이터 디렉토리. data/store/{Do}/{Si}/{Dong}/{Type}/{Title}.json
+const dataDir = "data/store"
+
+const dateLayout = "2006-01-02"
+
+// Record: data 디렉토리의 업소 파일 한개. 스키마는 data/store.schema.json 참고
+type Record struct {
+	// Schema: 에디터 자동완성용 스키마 경로. 서버에서는 사용하지 않음
+	Schema      string    `json:"$schema,omitempty"`
+	Location    *Location `json:"location"`
+	Type        string    `json:"type"`
+	Title       string    `json:"title"`
+	Slug        string    `json:"slug"`
+	FormerSlugs []string  `json:"formerSlugs,omitempty"`
+	// FormerPaths: 위치, 업종, 상호를 바꾸기 전의 이전 주소 형식 경로. ex) 서울/강남구/역삼동/쩜오/831
+	FormerPaths   []string `json:"formerPaths,omitempty"`
+	Description   string   `json:"description"`
+	Active        *Active  `json:"active"`
+	Hour          *Hour    `json:"hour"`
+	Menu          *Menu    `json:"menu"`
+	DatePublished string   `json:"datePublished"`
+	// PublishStatus: 공개 상태. draft, scheduled, published, archived. 없으면 published
+	PublishStatus string `json:"publishStatus,omitempty"`
+	// PublishAt: 공개 시각. ex) 2024-03-11 18:00
+	PublishAt string `json:"publishAt,omitempty"`
+	// Gallery: 업소 이미지. 관리자 화면에서 올리면 추가됨
+	Gallery []*media.Image `json:"gallery,omitempty"`
+	// History: 메뉴, 영업시간, 영업상태 변경 이력. 적용일 순서대로
+	History []*Revision `json:"history,omitempty"`
+}
+
+// errorList: 여러 레코드의 에러를 한번에 보고하기 위한 에러 목록
+type errorList []error
+
+func (l errorList) Error() string {
+	ss := make([]string, 0, len(l))
+	for _, err := range l {
+		ss = append(ss, err.Error())
+	}
+	return strings.Join(ss, "\n")
+}
+
+// recordError: 어느 파일의 레코드에서 발생한 에러인지 함께 보고
+type recordError struct {
+	Path string
+	Err  error
+}
+
+func (e *recordError) Error() string {
+	lines := strings.Split(e.Err.Error(), "\n")
+	for i, line := range lines {
+		lines[i] = fmt.Sprintf("%s: %s", e.Path, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (e *recordError) Unwrap() error { return e.Err }
+
+func parseDate(field, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("%s: 값이 없습니다", field)
+	}
+	t, err := time.ParseInLocation(dateLayout, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %q 형식이 올바르지 않습니다 (ex. 2024-03-11)", field, value)
+	}
+	return t, nil
+}
+
+// toStore: 필수 항목을 검사하고 Store로 변환. 파일 경로와 레코드 내용이 다르면 에러
+func (r *Record) toStore(rel string) (*Store, error) {
+	var errs errorList
+	if r.Location == nil {
+		errs = append(errs, fmt.Errorf("location: 값이 없습니다"))
+	}
+	if r.Type == "" {
+		errs = append(errs, fmt.Errorf("type: 값이 없습니다"))
+	}
+	if r.Title == "" {
+		errs = append(errs, fmt.Errorf("title: 값이 없습니다"))
+	}
+	if r.Slug == "" {
+		errs = append(errs, fmt.Errorf("slug: 값이 없습니다"))
+	}
+	if r.Active == nil {
+		errs = append(errs, fmt.Errorf("active: 값이 없습니다"))
+	}
+	if r.Hour == nil || r.Hour.Part1 == nil || r.Hour.Part2 == nil {
+		errs = append(errs, fmt.Errorf("hour: part1, part2 값이 필요합니다"))
+	}
+	if r.Menu == nil {
+		errs = append(errs, fmt.Errorf("menu: 값이 없습니다"))
+	}
+	datePublished, err := parseDate("datePublished", r.DatePublished)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	status, publishAt, err := parsePublication(r.PublishStatus, r.PublishAt)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for i, rev := range r.History {
+		if _, err := parseDate(fmt.Sprintf("history[%d].effective", i), rev.Effective); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for i, p := range r.FormerPaths {
+		if _, ok := parseLegacyPath(p); !ok {
+			errs = append(errs, fmt.Errorf("formerPaths[%d]: %q 도/시/동/업종/상호 형식이어야 합니다", i, p))
+		}
+	}
+	if r.Location != nil && r.Type != "" && r.Title != "" {
+		want := filepath.Join(r.Location.Do, r.Location.Si, r.Location.Dong, r.Type, r.Title+".json")
+		if rel != want {
+			errs = append(errs, fmt.Errorf("파일 경로가 레코드 내용과 다릅니다: %s 이어야 합니다", want))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	s := &Store{
+		Location:      r.Location,
+		Type:          r.Type,
+		Title:         r.Title,
+		Slug:          r.Slug,
+		FormerSlugs:   r.FormerSlugs,
+		FormerPaths:   r.FormerPaths,
+		Description:   r.Description,
+		Active:        r.Active,
+		Hour:          r.Hour,
+		Menu:          r.Menu,
+		DatePublished: datePublished,
+		DateModified:  datePublished,
+		PublishStatus: status,
+		PublishAt:     publishAt,
+		Gallery:       r.Gallery,
+		revisions:     r.History,
+		record:        r,
+	}
+	s.base = newBaseVersion(s)
+	return s, nil
+}
+
+func decodeRecord(path string) (*Record, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	r := &Record{}
+	if err := dec.Decode(r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// loadStores: dir 아래의 모든 *.json 업소 파일을 읽음. 에러가 있는 레코드는 모두 모아서 반환
+func loadStores(dir string) ([]*Store, error) {
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && filepath.Ext(path) == ".json" {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	list := []*Store{}
+	var errs errorList
+	for _, path := range paths {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return nil, err
+		}
+		r, err := decodeRecord(path)
+		if err != nil {
+			errs = append(errs, &recordError{Path: path, Err: err})
+			continue
+		}
+		s, err := r.toStore(rel)
+		if err != nil {
+			errs = append(errs, &recordError{Path: path, Err: err})
+			continue
+		}
+		list = append(list, s)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return list, nil
+}
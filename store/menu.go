@@ -0,0 +1,224 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dustin/go-humanize"
+)
+
+// Price: 원 단위 금액. Known=false면 가격 미공개(문의). 0원(무료)과 구분됨.
+// JSON에서는 숫자 또는 null(문의)
+type Price struct {
+	Amount int
+	Known  bool
+}
+
+func Won(amount int) Price { return Price{Amount: amount, Known: true} }
+
+// String: ex) ₩150,000, 문의
+func (p Price) String() string {
+	if !p.Known {
+		return "문의"
+	}
+	return "₩" + humanize.Comma(int64(p.Amount))
+}
+
+// Add: 둘 중 하나라도 문의면 문의
+func (p Price) Add(q Price) Price {
+	if !p.Known || !q.Known {
+		return Price{}
+	}
+	return Won(p.Amount + q.Amount)
+}
+
+func (p Price) Mul(n int) Price {
+	if !p.Known {
+		return Price{}
+	}
+	return Won(p.Amount * n)
+}
+
+func (p Price) MarshalJSON() ([]byte, error) {
+	if !p.Known {
+		return []byte("null"), nil
+	}
+	return json.Marshal(p.Amount)
+}
+
+func (p *Price) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		*p = Price{}
+		return nil
+	}
+	var n int
+	if err := json.Unmarshal(b, &n); err != nil {
+		return fmt.Errorf("가격은 정수 또는 null(문의)이어야 합니다")
+	}
+	*p = Won(n)
+	return nil
+}
+
+// ChargeUnit: 요금 부과 단위
+type ChargeUnit string
+
+const (
+	PER_PERSON ChargeUnit = "person"
+	PER_ROOM   ChargeUnit = "room"
+)
+
+// Label: ex) 1인당
+func (u ChargeUnit) Label() string {
+	switch u {
+	case PER_PERSON:
+		return "1인당"
+	case PER_ROOM:
+		return "룸당"
+	}
+	return string(u)
+}
+
+type Charge struct {
+	Price Price      `json:"price"`
+	Per   ChargeUnit `json:"per"`
+}
+
+// For: people명이 룸 하나를 쓸때의 금액
+func (c *Charge) For(people int) Price {
+	if c == nil {
+		return Price{}
+	}
+	if c.Per == PER_PERSON {
+		return c.Price.Mul(people)
+	}
+	return c.Price
+}
+
+// Bottle: 주대(양주 세트) 등급 하나
+type Bottle struct {
+	// Name: ex) 양주 세트, 발렌타인 17년
+	Name  string `json:"name"`
+	Price Price  `json:"price"`
+}
+
+// Surcharge: 특정 요일에 추가되는 요금. ex) 금, 토 룸당 20,000원
+type Surcharge struct {
+	Days []Weekday `json:"days"`
+	Charge
+}
+
+// VAT 표기
+const (
+	VAT_INCLUDED = "included"
+	VAT_EXCLUDED = "excluded"
+)
+
+type Menu struct {
+	// Currency: 통화. 현재는 KRW만 사용
+	Currency string `json:"currency"`
+	// VAT: included(부가세 포함), excluded(부가세 별도), 빈값(표기 안함)
+	VAT string `json:"vat,omitempty"`
+	// Part1: 1부 주대. 첫번째가 기본 세트
+	Part1 []*Bottle `json:"part1"`
+	// Part2: 2부 주대. 첫번째가 기본 세트
+	Part2 []*Bottle `json:"part2"`
+	// TC: 아가씨 티시
+	TC *Charge `json:"tc"`
+	// RT: 룸비
+	RT *Charge `json:"rt"`
+	// Weekend: 주말 추가 요금
+	Weekend *Surcharge `json:"weekend,omitempty"`
+}
+
+// VATLabel: ex) 부가세 포함
+func (m *Menu) VATLabel() string {
+	switch m.VAT {
+	case VAT_INCLUDED:
+		return "부가세 포함"
+	case VAT_EXCLUDED:
+		return "부가세 별도"
+	}
+	return ""
+}
+
+// Bottles: part부의 주대 목록
+func (m *Menu) Bottles(part int) []*Bottle {
+	if part == 2 {
+		return m.Part2
+	}
+	return m.Part1
+}
+
+// BottlePrice: part부 기본 세트의 주대
+func (m *Menu) BottlePrice(part int) Price {
+	if bottles := m.Bottles(part); len(bottles) > 0 {
+		return bottles[0].Price
+	}
+	return Price{}
+}
+
+// LineItem: 견적의 항목 하나
+type LineItem struct {
+	// Name: ex) 주대, TC, RT
+	Name  string
+	Price Price
+}
+
+// Quote: part부에 people명이 기본 세트로 입실할때의 견적
+type Quote struct {
+	Part   int
+	People int
+	Items  []*LineItem
+	Total  Price
+}
+
+// Quote: 추가 요금이 없는 날의 견적. 합계는 항목 중 하나라도 문의면 문의
+func (m *Menu) Quote(part, people int) *Quote {
+	q := &Quote{Part: part, People: people}
+	q.Items = append(q.Items,
+		&LineItem{Name: "주대", Price: m.BottlePrice(part)},
+		&LineItem{Name: "TC", Price: m.TC.For(people)},
+		&LineItem{Name: "RT", Price: m.RT.For(people)},
+	)
+	q.total()
+	return q
+}
+
+// QuoteOn: day 요일의 견적. 주말 추가 요금 대상 요일이면 항목에 포함
+func (m *Menu) QuoteOn(part, people int, day Weekday) *Quote {
+	q := m.Quote(part, people)
+	if m.Weekend != nil && m.Weekend.applies(day) {
+		q.Items = append(q.Items, &LineItem{Name: "주말 추가", Price: m.Weekend.For(people)})
+		q.total()
+	}
+	return q
+}
+
+func (q *Quote) total() {
+	q.Total = Won(0)
+	for _, item := range q.Items {
+		q.Total = q.Total.Add(item.Price)
+	}
+}
+
+func (s *Surcharge) applies(day Weekday) bool {
+	for _, d := range s.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// 업소 페이지에 보여주는 인원수 별 가격표의 최대 인원
+const quoteMaxPeople = 4
+
+// Quotes: 업소 페이지의 인원수 별 가격표. 1명부터 quoteMaxPeople명까지 추가 요금 없는 날 기준
+func (s *Store) Quotes(part int) []*Quote {
+	list := make([]*Quote, 0, quoteMaxPeople)
+	for people := 1; people <= quoteMaxPeople; people++ {
+		list = append(list, s.Menu.Quote(part, people))
+	}
+	return list
+}
@@ -0,0 +1,155 @@
+package store
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// quoteMenu: 1부 양주 세트 150,000 / 발렌타인 17년 250,000, 2부 양주 세트 100,000,
+// TC 1인당 100,000, RT 룸당 30,000, 금, 토 1인당 20,000 추가
+func quoteMenu() *Menu {
+	return &Menu{
+		Currency: "KRW",
+		Part1:    []*Bottle{{Name: "양주 세트", Price: Won(150000)}, {Name: "발렌타인 17년", Price: Won(250000)}},
+		Part2:    []*Bottle{{Name: "양주 세트", Price: Won(100000)}},
+		TC:       &Charge{Price: Won(100000), Per: PER_PERSON},
+		RT:       &Charge{Price: Won(30000), Per: PER_ROOM},
+		Weekend: &Surcharge{
+			Days:   []Weekday{Weekday(time.Friday), Weekday(time.Saturday)},
+			Charge: Charge{Price: Won(20000), Per: PER_PERSON},
+		},
+	}
+}
+
+// quoteItems: ex) 주대=₩150,000
+func quoteItems(q *Quote) []string {
+	list := []string{}
+	for _, item := range q.Items {
+		list = append(list, item.Name+"="+item.Price.String())
+	}
+	return list
+}
+
+func TestQuote(t *testing.T) {
+	inquiryTC := quoteMenu()
+	inquiryTC.TC = &Charge{Price: Price{}, Per: PER_PERSON}
+	noRT := quoteMenu()
+	noRT.RT = nil
+	perPersonRT := quoteMenu()
+	perPersonRT.RT = &Charge{Price: Won(30000), Per: PER_PERSON}
+	freeRT := quoteMenu()
+	freeRT.RT = &Charge{Price: Won(0), Per: PER_ROOM}
+	noPart2 := quoteMenu()
+	noPart2.Part2 = nil
+	tests := []struct {
+		name   string
+		m      *Menu
+		part   int
+		people int
+		items  []string
+		total  string
+	}{
+		{"1명", quoteMenu(), 1, 1, []string{"주대=₩150,000", "TC=₩100,000", "RT=₩30,000"}, "₩280,000"},
+		// TC는 인원수만큼, RT는 룸 하나
+		{"3명", quoteMenu(), 1, 3, []string{"주대=₩150,000", "TC=₩300,000", "RT=₩30,000"}, "₩480,000"},
+		{"1인당 RT", perPersonRT, 1, 3, []string{"주대=₩150,000", "TC=₩300,000", "RT=₩90,000"}, "₩540,000"},
+		// 기본 세트는 첫번째 등급
+		{"2부", quoteMenu(), 2, 2, []string{"주대=₩100,000", "TC=₩200,000", "RT=₩30,000"}, "₩330,000"},
+		// 항목 하나가 문의면 합계도 문의
+		{"TC 문의", inquiryTC, 1, 2, []string{"주대=₩150,000", "TC=문의", "RT=₩30,000"}, "문의"},
+		{"RT 없음", noRT, 1, 2, []string{"주대=₩150,000", "TC=₩200,000", "RT=문의"}, "문의"},
+		{"2부 주대 없음", noPart2, 2, 1, []string{"주대=문의", "TC=₩100,000", "RT=₩30,000"}, "문의"},
+		// 0원은 문의가 아님
+		{"RT 무료", freeRT, 1, 1, []string{"주대=₩150,000", "TC=₩100,000", "RT=₩0"}, "₩250,000"},
+	}
+	for _, tt := range tests {
+		q := tt.m.Quote(tt.part, tt.people)
+		if q.Part != tt.part || q.People != tt.people {
+			t.Errorf("%s: Part, People = %d, %d, want %d, %d", tt.name, q.Part, q.People, tt.part, tt.people)
+		}
+		if got := quoteItems(q); !reflect.DeepEqual(got, tt.items) {
+			t.Errorf("%s: Items = %v, want %v", tt.name, got, tt.items)
+		}
+		if q.Total.String() != tt.total {
+			t.Errorf("%s: Total = %s, want %s", tt.name, q.Total, tt.total)
+		}
+	}
+}
+
+func TestQuoteOn(t *testing.T) {
+	inquiry := quoteMenu()
+	inquiry.Weekend.Price = Price{}
+	perRoom := quoteMenu()
+	perRoom.Weekend.Per = PER_ROOM
+	noWeekend := quoteMenu()
+	noWeekend.Weekend = nil
+	tests := []struct {
+		name  string
+		m     *Menu
+		day   time.Weekday
+		items []string
+		total string
+	}{
+		{"평일", quoteMenu(), time.Thursday, []string{"주대=₩150,000", "TC=₩200,000", "RT=₩30,000"}, "₩380,000"},
+		{"금요일 1인당", quoteMenu(), time.Friday, []string{"주대=₩150,000", "TC=₩200,000", "RT=₩30,000", "주말 추가=₩40,000"}, "₩420,000"},
+		{"토요일 룸당", perRoom, time.Saturday, []string{"주대=₩150,000", "TC=₩200,000", "RT=₩30,000", "주말 추가=₩20,000"}, "₩400,000"},
+		{"추가 요금 문의", inquiry, time.Saturday, []string{"주대=₩150,000", "TC=₩200,000", "RT=₩30,000", "주말 추가=문의"}, "문의"},
+		{"추가 요금 없음", noWeekend, time.Saturday, []string{"주대=₩150,000", "TC=₩200,000", "RT=₩30,000"}, "₩380,000"},
+	}
+	for _, tt := range tests {
+		q := tt.m.QuoteOn(1, 2, Weekday(tt.day))
+		if got := quoteItems(q); !reflect.DeepEqual(got, tt.items) {
+			t.Errorf("%s: Items = %v, want %v", tt.name, got, tt.items)
+		}
+		if q.Total.String() != tt.total {
+			t.Errorf("%s: Total = %s, want %s", tt.name, q.Total, tt.total)
+		}
+	}
+}
+
+func TestBottlePrice(t *testing.T) {
+	m := quoteMenu()
+	if got := m.BottlePrice(1); got != Won(150000) {
+		t.Errorf("BottlePrice(1) = %s, want ₩150,000", got)
+	}
+	if got := len(m.Bottles(1)); got != 2 {
+		t.Errorf("len(Bottles(1)) = %d, want 2", got)
+	}
+	m.Part2 = []*Bottle{}
+	if got := m.BottlePrice(2); got.Known {
+		t.Errorf("BottlePrice(2) = %s, want 문의", got)
+	}
+}
+
+func TestPriceJSON(t *testing.T) {
+	tests := []struct {
+		json string
+		want Price
+	}{
+		{"150000", Won(150000)},
+		{"0", Won(0)},
+		{"null", Price{}},
+	}
+	for _, tt := range tests {
+		var p Price
+		if err := json.Unmarshal([]byte(tt.json), &p); err != nil {
+			t.Fatal(err)
+		}
+		if p != tt.want {
+			t.Errorf("Unmarshal(%s) = %+v, want %+v", tt.json, p, tt.want)
+		}
+		b, err := json.Marshal(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != tt.json {
+			t.Errorf("Marshal(%+v) = %s, want %s", p, b, tt.json)
+		}
+	}
+	var p Price
+	if err := json.Unmarshal([]byte(`"문의"`), &p); err == nil {
+		t.Error(`Unmarshal("문의") 에러가 없습니다`)
+	}
+}
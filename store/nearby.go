@@ -0,0 +1,127 @@
+package store
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// EARTH_RADIUS: 지구 평균 반지름(m)
+	EARTH_RADIUS = 6371008.8
+	// GRID_CELL_DEGREES: 근처 업소 인덱스 칸 크기(도). 위도 0.01도는 약 1.1km
+	GRID_CELL_DEGREES = 0.01
+	// NEARBY_MAX_RADIUS: 근처 업소를 찾는 최대 반경(m)
+	NEARBY_MAX_RADIUS = 20000
+)
+
+// metersPerDegree: 위도 1도의 거리(m)
+const metersPerDegree = EARTH_RADIUS * math.Pi / 180
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+
+// Distance: a, b 사이의 거리(m). haversine 공식
+func Distance(a, b *Geo) float64 {
+	dLat := radians(b.Lat - a.Lat)
+	dLng := radians(b.Lng - a.Lng)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(radians(a.Lat))*math.Cos(radians(b.Lat))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * EARTH_RADIUS * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// gridCell: 위도, 경도를 GRID_CELL_DEGREES로 나눈 칸
+type gridCell struct{ lat, lng int }
+
+func cellOf(lat, lng float64) gridCell {
+	return gridCell{int(math.Floor(lat / GRID_CELL_DEGREES)), int(math.Floor(lng / GRID_CELL_DEGREES))}
+}
+
+// buildGrid: 좌표가 있고 폐업하지 않은 업소의 칸별 목록
+func buildGrid(stores []*Store) map[gridCell][]*Store {
+	grid := map[gridCell][]*Store{}
+	for _, s := range stores {
+		g := s.Location.LatLng()
+		if g == nil || s.Active.IsPermanentClosed {
+			continue
+		}
+		cell := cellOf(g.Lat, g.Lng)
+		grid[cell] = append(grid[cell], s)
+	}
+	return grid
+}
+
+// NearbyQuery: 근처 업소 검색 조건
+type NearbyQuery struct {
+	Center *Geo
+	// Radius: 반경(m). NEARBY_MAX_RADIUS를 넘으면 NEARBY_MAX_RADIUS
+	Radius float64
+	// Type: 업종. 비어있으면 모든 업종
+	Type string
+	// OpenAt: 이 시각에 영업중인 업소만. zero면 영업시간과 관계없이
+	OpenAt time.Time
+	// Exclude: 결과에서 뺄 업소. ex) 지금 보고있는 업소
+	Exclude *Store
+	// Limit: 최대 개수. 0이면 제한 없음
+	Limit int
+}
+
+// NearbyStore: 근처 업소와 거리
+type NearbyStore struct {
+	Store *Store
+	// Distance: Center부터의 거리(m)
+	Distance float64
+}
+
+// DistanceLabel: ex) 350m, 1.2km
+func (n *NearbyStore) DistanceLabel() string {
+	if n.Distance < 1000 {
+		return fmt.Sprintf("%dm", int(math.Round(n.Distance/10)*10))
+	}
+	return fmt.Sprintf("%.1fkm", n.Distance/1000)
+}
+
+// Nearby: q.Center에서 반경 안의 공개중이고 폐업하지 않은 업소. 가까운 순서, 거리가 같으면 Slug 순서
+func (c *Catalog) Nearby(q NearbyQuery) []*NearbyStore {
+	list := []*NearbyStore{}
+	if q.Center == nil || q.Radius <= 0 {
+		return list
+	}
+	radius := math.Min(q.Radius, NEARBY_MAX_RADIUS)
+	dLat := radius / metersPerDegree
+	// 극지방에서 경도 범위가 무한대가 되지 않도록
+	dLng := radius / (metersPerDegree * math.Max(math.Cos(radians(q.Center.Lat)), 0.01))
+	min, max := cellOf(q.Center.Lat-dLat, q.Center.Lng-dLng), cellOf(q.Center.Lat+dLat, q.Center.Lng+dLng)
+	for lat := min.lat; lat <= max.lat; lat++ {
+		for lng := min.lng; lng <= max.lng; lng++ {
+			for _, s := range c.grid[gridCell{lat, lng}] {
+				if s == q.Exclude || (q.Type != "" && s.Type != q.Type) {
+					continue
+				}
+				d := Distance(q.Center, s.Location.LatLng())
+				if d > radius {
+					continue
+				}
+				if !q.OpenAt.IsZero() && !s.IsOpenAt(q.OpenAt) {
+					continue
+				}
+				list = append(list, &NearbyStore{Store: s, Distance: d})
+			}
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Distance != list[j].Distance {
+			return list[i].Distance < list[j].Distance
+		}
+		return list[i].Store.Slug < list[j].Store.Slug
+	})
+	if q.Limit > 0 && len(list) > q.Limit {
+		list = list[:q.Limit]
+	}
+	return list
+}
+
+// NearbyStores: s 근처의 다른 업소. s에 좌표가 없으면 빈 목록
+func (c *Catalog) NearbyStores(s *Store, radius float64, limit int) []*NearbyStore {
+	return c.Nearby(NearbyQuery{Center: s.Location.LatLng(), Radius: radius, Exclude: s, Limit: limit})
+}
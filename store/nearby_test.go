@@ -0,0 +1,138 @@
+package store
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// nearbyCenter: 격자 칸 경계(위도 37.50, 경도 127.03) 바로 안쪽
+var nearbyCenter = &Geo{Lat: 37.4999, Lng: 127.0299}
+
+// nearbyStore: 테스트용 업소. open ~ closed 매일 1부 영업
+func nearbyStore(t *testing.T, slug, storeType string, lat, lng float64, open, closed string) *Store {
+	t.Helper()
+	s := &Store{
+		Location: &Location{Do: "서울", Si: "강남구", Dong: "역삼동", Geo: &Geo{Lat: lat, Lng: lng}},
+		Type:     storeType,
+		Title:    slug,
+		Slug:     slug,
+		Active:   &Active{},
+		Hour:     &Hour{Part1: &TimeType{Has: true, Open: open, Closed: closed}},
+		public:   true,
+	}
+	if err := s.Hour.compile(); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func nearbySlugs(list []*NearbyStore) []string {
+	slugs := []string{}
+	for _, n := range list {
+		slugs = append(slugs, n.Store.Slug)
+	}
+	return slugs
+}
+
+func TestNearby(t *testing.T) {
+	// 0.0045도 ≒ 500.4m
+	north := nearbyStore(t, "north-500m", STORE_TYPE_DOT5, nearbyCenter.Lat+0.0045, nearbyCenter.Lng, "18:00", "05:00")
+	// 위도, 경도 모두 옆 칸. 약 28m
+	corner := nearbyStore(t, "corner", STORE_TYPE_DOT5, 37.5001, 127.0301, "18:00", "05:00")
+	// 경도만 옆 칸. 약 18m
+	east := nearbyStore(t, "east", STORE_TYPE_CLUB, nearbyCenter.Lat, 127.0301, "10:00", "17:00")
+	// east와 같은 거리
+	twin := nearbyStore(t, "a-twin", STORE_TYPE_CLUB, nearbyCenter.Lat, 127.0297, "10:00", "17:00")
+	far := nearbyStore(t, "far", STORE_TYPE_DOT5, nearbyCenter.Lat+0.05, nearbyCenter.Lng, "18:00", "05:00")
+	closed := nearbyStore(t, "closed", STORE_TYPE_DOT5, 37.5001, 127.0299, "18:00", "05:00")
+	closed.Active = &Active{IsPermanentClosed: true, Reason: "정상폐업"}
+	noGeo := nearbyStore(t, "no-geo", STORE_TYPE_DOT5, 0, 0, "18:00", "05:00")
+	noGeo.Location.Geo = nil
+	c := buildIndexes([]*Store{north, corner, east, twin, far, closed, noGeo}, nil)
+
+	at := time.Date(2024, 3, 12, 2, 0, 0, 0, time.Local)
+	tests := []struct {
+		name string
+		q    NearbyQuery
+		want []string
+	}{
+		{"반경 밖", NearbyQuery{Radius: 500}, []string{"a-twin", "east", "corner"}},
+		{"반경 안", NearbyQuery{Radius: 501}, []string{"a-twin", "east", "corner", "north-500m"}},
+		{"최대 반경으로 제한", NearbyQuery{Radius: 1e9}, []string{"a-twin", "east", "corner", "north-500m", "far"}},
+		{"업종", NearbyQuery{Radius: 1000, Type: STORE_TYPE_CLUB}, []string{"a-twin", "east"}},
+		{"개수", NearbyQuery{Radius: 1000, Limit: 2}, []string{"a-twin", "east"}},
+		{"제외", NearbyQuery{Radius: 1000, Exclude: corner}, []string{"a-twin", "east", "north-500m"}},
+		{"새벽 2시 영업중", NearbyQuery{Radius: 1000, OpenAt: at}, []string{"corner", "north-500m"}},
+		{"오후 3시 영업중", NearbyQuery{Radius: 1000, OpenAt: at.Add(13 * time.Hour)}, []string{"a-twin", "east"}},
+		{"반경 0", NearbyQuery{Radius: 0}, []string{}},
+	}
+	for _, tt := range tests {
+		tt.q.Center = nearbyCenter
+		got := c.Nearby(tt.q)
+		if slugs := nearbySlugs(got); !reflect.DeepEqual(slugs, tt.want) {
+			t.Errorf("%s: Nearby() = %v, want %v", tt.name, slugs, tt.want)
+			continue
+		}
+		for i := 1; i < len(got); i++ {
+			if got[i-1].Distance > got[i].Distance {
+				t.Errorf("%s: 가까운 순서가 아닙니다: %v > %v", tt.name, got[i-1].Distance, got[i].Distance)
+			}
+		}
+	}
+	if got := c.Nearby(NearbyQuery{Radius: 1000}); len(got) != 0 {
+		t.Errorf("Center가 없으면 빈 목록이어야 합니다: %v", nearbySlugs(got))
+	}
+}
+
+func TestNearbyStores(t *testing.T) {
+	a := nearbyStore(t, "a", STORE_TYPE_DOT5, 37.4999, 127.0299, "18:00", "05:00")
+	b := nearbyStore(t, "b", STORE_TYPE_DOT5, 37.5001, 127.0301, "18:00", "05:00")
+	noGeo := nearbyStore(t, "no-geo", STORE_TYPE_DOT5, 0, 0, "18:00", "05:00")
+	noGeo.Location.Geo = nil
+	c := buildIndexes([]*Store{a, b, noGeo}, nil)
+	if got := nearbySlugs(c.NearbyStores(a, 1000, 0)); !reflect.DeepEqual(got, []string{"b"}) {
+		t.Errorf("NearbyStores(a) = %v, want [b]", got)
+	}
+	if got := c.NearbyStores(noGeo, 1000, 0); len(got) != 0 {
+		t.Errorf("NearbyStores(no-geo) = %v, want []", nearbySlugs(got))
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b *Geo
+		want float64
+	}{
+		{"같은 좌표", &Geo{Lat: 37.5, Lng: 127}, &Geo{Lat: 37.5, Lng: 127}, 0},
+		{"위도 1도", &Geo{Lat: 37, Lng: 127}, &Geo{Lat: 38, Lng: 127}, metersPerDegree},
+		{"적도 경도 1도", &Geo{Lat: 0, Lng: 127}, &Geo{Lat: 0, Lng: 128}, metersPerDegree},
+		// 위도 60도에서 경도 1도는 적도의 절반
+		{"위도 60도 경도 1도", &Geo{Lat: 60, Lng: 127}, &Geo{Lat: 60, Lng: 128}, metersPerDegree / 2},
+	}
+	for _, tt := range tests {
+		got := Distance(tt.a, tt.b)
+		if math.Abs(got-tt.want) > 0.001*tt.want+1e-6 {
+			t.Errorf("%s: Distance() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDistanceLabel(t *testing.T) {
+	tests := []struct {
+		distance float64
+		want     string
+	}{
+		{0, "0m"},
+		{344, "340m"},
+		{1000, "1.0km"},
+		{1250, "1.2km"},
+	}
+	for _, tt := range tests {
+		if got := (&NearbyStore{Distance: tt.distance}).DistanceLabel(); got != tt.want {
+			t.Errorf("DistanceLabel(%v) = %s, want %s", tt.distance, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package store
+
+import (
+	"fmt"
+	"hash/fnv"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// 소개글 변수. ex) [[title]] [[si]] [[part1.price]]
+var placeholderPattern = regexp.MustCompile(`\[\[([^\[\]]*)\]\]`)
+
+// placeholders: 소개글에서 쓸 수 있는 변수. Store의 현재 값(Catalog를 만든 시점)으로 바뀜
+var placeholders = map[string]func(s *Store) string{
+	// title: ex) 에이원
+	"title": func(s *Store) string { return s.Title },
+	// type: ex) 쩜오
+	"type": func(s *Store) string { return s.Type },
+	// do: ex) 서울
+	"do": func(s *Store) string { return s.Location.Do },
+	// si: 짧은 시 이름. ex) 강남
+	"si": func(s *Store) string { return s.Region.Si().Short },
+	// dong: ex) 역삼동
+	"dong": func(s *Store) string { return s.Location.Dong },
+	// part1.price: 1부 기본 세트 주대. ex) ₩150,000, 문의
+	"part1.price": func(s *Store) string { return s.Menu.BottlePrice(1).String() },
+	"part2.price": func(s *Store) string { return s.Menu.BottlePrice(2).String() },
+	// tc: ex) ₩100,000 (1인당)
+	"tc": func(s *Store) string { return chargeText(s.Menu.TC) },
+	"rt": func(s *Store) string { return chargeText(s.Menu.RT) },
+	// part1.hour: ex) 18:00~05:00, 영업 안함
+	"part1.hour": func(s *Store) string { return hourText(s.Hour.Part1) },
+	"part2.hour": func(s *Store) string { return hourText(s.Hour.Part2) },
+}
+
+func chargeText(c *Charge) string {
+	if c == nil || !c.Price.Known {
+		return Price{}.String()
+	}
+	return fmt.Sprintf("%s (%s)", c.Price, c.Per.Label())
+}
+
+func hourText(t *TimeType) string {
+	if t == nil || !t.Has {
+		return "영업 안함"
+	}
+	return t.Open + "~" + t.Closed
+}
+
+// PlaceholderNames: 사용할 수 있는 변수 이름. 에러 메시지에 사용
+func PlaceholderNames() []string {
+	names := make([]string, 0, len(placeholders))
+	for name := range placeholders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// spinner: {a|b|c} 문구 중 하나를 고름. 같은 업소는 항상 같은 문구, 업소마다 다른 문구가 나오도록
+// 업소 slug와 문구의 순서로 고름
+type spinner struct {
+	seed string
+	n    int
+}
+
+func (sp *spinner) choose(options int) int {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%s#%d", sp.seed, sp.n)
+	sp.n++
+	// FNV는 마지막 byte가 거의 섞이지 않아서 n만 다른 선택끼리 결과가 묶임. murmur3 finalizer로 섞어서 고름
+	x := h.Sum32()
+	x ^= x >> 16
+	x *= 0x85ebca6b
+	x ^= x >> 13
+	x *= 0xc2b2ae35
+	x ^= x >> 16
+	return int(x % uint32(options))
+}
+
+// escaped: text[i]가 \{ \} \| 의 \ 인지
+func escaped(text string, i int) bool {
+	return text[i] == '\\' && i+1 < len(text) && strings.IndexByte("{}|", text[i+1]) >= 0
+}
+
+// closingBrace: text[i]의 { 에 맞는 } 위치. 없으면 -1
+func closingBrace(text string, i int) int {
+	depth := 0
+	for j := i; j < len(text); j++ {
+		if escaped(text, j) {
+			j++
+			continue
+		}
+		switch text[j] {
+		case '{':
+			depth++
+		case '}':
+			if depth--; depth == 0 {
+				return j
+			}
+		}
+	}
+	return -1
+}
+
+// splitOptions: 중첩되지 않은 | 로 나눔
+func splitOptions(text string) []string {
+	var options []string
+	depth, start := 0, 0
+	for j := 0; j < len(text); j++ {
+		if escaped(text, j) {
+			j++
+			continue
+		}
+		switch text[j] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case '|':
+			if depth == 0 {
+				options = append(options, text[start:j])
+				start = j + 1
+			}
+		}
+	}
+	return append(options, text[start:])
+}
+
+// spin: {a|b|c}를 하나로 바꿈. 중첩 가능. | 가 없는 {...}, 짝이 없는 { 는 그대로 둠.
+// \{ \} \| 는 문구로 보지 않고 { } | 로 바꿈
+func (sp *spinner) spin(text string) string {
+	var b strings.Builder
+	for i := 0; i < len(text); i++ {
+		if escaped(text, i) {
+			b.WriteByte(text[i+1])
+			i++
+			continue
+		}
+		end := -1
+		if text[i] == '{' {
+			end = closingBrace(text, i)
+		}
+		if end < 0 {
+			b.WriteByte(text[i])
+			continue
+		}
+		options := splitOptions(text[i+1 : end])
+		if len(options) < 2 {
+			b.WriteString("{" + sp.spin(options[0]) + "}")
+		} else {
+			b.WriteString(sp.spin(options[sp.choose(len(options))]))
+		}
+		i = end
+	}
+	return b.String()
+}
+
+// expandArticle: 소개글의 {a|b|c} 문구를 고르고 [[변수]]를 s의 값으로 바꿈. escape면 값을 HTML escape.
+// 모르는 변수가 있으면 에러
+func expandArticle(s *Store, text string, escape bool) (string, error) {
+	text = (&spinner{seed: s.Slug}).spin(text)
+	var unknown []string
+	text = placeholderPattern.ReplaceAllStringFunc(text, func(m string) string {
+		name := strings.TrimSpace(m[2 : len(m)-2])
+		f, has := placeholders[name]
+		if !has {
+			unknown = append(unknown, m)
+			return m
+		}
+		if escape {
+			return html.EscapeString(f(s))
+		}
+		return f(s)
+	})
+	if len(unknown) > 0 {
+		return "", fmt.Errorf("%s: 알 수 없는 변수입니다 (%s)", strings.Join(unknown, ", "), strings.Join(PlaceholderNames(), ", "))
+	}
+	return text, nil
+}
@@ -0,0 +1,123 @@
+package store
+
+import (
+	"strings"
+	"testing"
+)
+
+// spinText: 문구 8개가 각각 2~4개 중 하나
+const spinText = "{강남|역삼} {최고의|인기} {쩜오|룸|클럽} {입니다|예요}. " +
+	"{가격|주대}{은|는} {합리적|착함}. {지금|오늘|바로|언제든} {전화|문의} 주세요."
+
+func TestSpinDeterministic(t *testing.T) {
+	a := (&spinner{seed: "yeoksam-jjeomo-831"}).spin(spinText)
+	// 다시 읽어서 Catalog를 새로 만들어도 같은 slug면 같은 문구
+	if b := (&spinner{seed: "yeoksam-jjeomo-831"}).spin(spinText); a != b {
+		t.Errorf("같은 seed인데 결과가 다릅니다:\n%s\n%s", a, b)
+	}
+	if c := (&spinner{seed: "yeoksam-jjeomo-aone"}).spin(spinText); a == c {
+		t.Errorf("다른 seed인데 결과가 같습니다: %s", a)
+	}
+	if strings.ContainsAny(a, "{|}") {
+		t.Errorf("고르지 않은 문구가 남았습니다: %s", a)
+	}
+}
+
+func TestExpandArticleStable(t *testing.T) {
+	text := "<p>{[[title]]|[[title]] [[type]]}{은|는} {강남|역삼}에 있습니다</p>"
+	newStore := func() *Store {
+		return &Store{Location: &Location{Dong: "역삼동"}, Type: STORE_TYPE_DOT5, Title: "831", Slug: "yeoksam-jjeomo-831"}
+	}
+	a, err := expandArticle(newStore(), text, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 같은 업소를 다시 읽은 경우
+	b, err := expandArticle(newStore(), text, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != b {
+		t.Errorf("다시 읽은 업소의 결과가 다릅니다:\n%s\n%s", a, b)
+	}
+}
+
+func TestSpin(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		// want: 나올 수 있는 결과
+		want []string
+	}{
+		{"문구 없음", "강남 쩜오", []string{"강남 쩜오"}},
+		{"하나", "{a|b}", []string{"a", "b"}},
+		{"중첩", "{a|{b|c}}", []string{"a", "b", "c"}},
+		{"중첩 앞뒤 문자", "x{a|y{b|c}z}", []string{"xa", "xybz", "xycz"}},
+		{"빈 문구", "a{|b}", []string{"a", "ab"}},
+		{"| 없는 괄호", "p { color: red; }", []string{"p { color: red; }"}},
+		{"짝이 없는 괄호", "{a|b", []string{"{a|b"}},
+		{"escape", `\{a|b\}`, []string{"{a|b}"}},
+		{"문구 안의 escape", `{\{a\}|b\|c}`, []string{"{a}", "b|c"}},
+		{"escape 아닌 \\", `a\b {c|d}`, []string{`a\bc`, `a\b c`, `a\b d`}},
+	}
+	for _, tt := range tests {
+		for _, seed := range []string{"a", "b", "c", "d", "e", "f"} {
+			got := (&spinner{seed: seed}).spin(tt.text)
+			ok := false
+			for _, w := range tt.want {
+				ok = ok || got == w
+			}
+			if !ok {
+				t.Errorf("%s: spin(%q) = %q, want %q 중 하나", tt.name, tt.text, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestSpinNestedCoversAll(t *testing.T) {
+	seen := map[string]bool{}
+	for _, seed := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l"} {
+		seen[(&spinner{seed: seed}).spin("{a|{b|c}}")] = true
+	}
+	for _, w := range []string{"a", "b", "c"} {
+		if !seen[w] {
+			t.Errorf("{a|{b|c}}: %q 가 한번도 나오지 않았습니다 (%v)", w, seen)
+		}
+	}
+}
+
+func TestExpandArticle(t *testing.T) {
+	s := &Store{
+		Location: &Location{Do: "서울", Dong: "역삼동"},
+		Type:     STORE_TYPE_DOT5,
+		Title:    "A&B",
+		Slug:     "yeoksam-jjeomo-ab",
+		Menu:     &Menu{Part1: []*Bottle{{Name: "양주 세트", Price: Won(150000)}}, TC: &Charge{Price: Won(100000), Per: PER_PERSON}},
+		Hour:     &Hour{Part1: &TimeType{Has: true, Open: "18:00", Closed: "05:00"}, Part2: &TimeType{Has: false}},
+	}
+	tests := []struct {
+		text   string
+		escape bool
+		want   string
+	}{
+		{"[[title]] [[type]]", false, "A&B 쩜오"},
+		{"[[title]]", true, "A&amp;B"},
+		{"[[ dong ]] [[do]]", false, "역삼동 서울"},
+		{"[[part1.price]] / [[part2.price]]", false, "₩150,000 / 문의"},
+		{"[[tc]] / [[rt]]", false, "₩100,000 (1인당) / 문의"},
+		{"[[part1.hour]] / [[part2.hour]]", false, "18:00~05:00 / 영업 안함"},
+	}
+	for _, tt := range tests {
+		got, err := expandArticle(s, tt.text, tt.escape)
+		if err != nil {
+			t.Errorf("%s: %v", tt.text, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("expandArticle(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+	if _, err := expandArticle(s, "[[title]] [[phone]]", false); err == nil || !strings.Contains(err.Error(), "[[phone]]") {
+		t.Errorf("모르는 변수 에러 = %v", err)
+	}
+}
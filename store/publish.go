@@ -0,0 +1,103 @@
+package store
+
+import (
+	"fmt"
+	"time"
+)
+
+// PublishStatus: 공개 상태
+type PublishStatus string
+
+const (
+	// PUBLISH_DRAFT: 작성중. 공개하지 않음
+	PUBLISH_DRAFT PublishStatus = "draft"
+	// PUBLISH_SCHEDULED: PublishAt에 자동으로 공개
+	PUBLISH_SCHEDULED PublishStatus = "scheduled"
+	// PUBLISH_PUBLISHED: 공개. PublishAt이 있으면 그 시각부터
+	PUBLISH_PUBLISHED PublishStatus = "published"
+	// PUBLISH_ARCHIVED: 공개 종료. 업소 페이지는 410
+	PUBLISH_ARCHIVED PublishStatus = "archived"
+)
+
+var publishStatuses = []PublishStatus{PUBLISH_DRAFT, PUBLISH_SCHEDULED, PUBLISH_PUBLISHED, PUBLISH_ARCHIVED}
+
+// PublishStatuses: 관리자 화면의 선택 목록
+func PublishStatuses() []PublishStatus { return publishStatuses }
+
+// Label: ex) 예약
+func (s PublishStatus) Label() string {
+	switch s {
+	case PUBLISH_DRAFT:
+		return "작성중"
+	case PUBLISH_SCHEDULED:
+		return "예약"
+	case PUBLISH_PUBLISHED:
+		return "공개"
+	case PUBLISH_ARCHIVED:
+		return "공개 종료"
+	}
+	return string(s)
+}
+
+// publishAtLayout: data 파일의 publishAt 형식. 한국 시간
+const publishAtLayout = "2006-01-02 15:04"
+
+func parsePublishAt(value string) (time.Time, error) {
+	t, err := time.ParseInLocation(publishAtLayout, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("publishAt: %q 형식이 올바르지 않습니다 (ex. 2024-03-11 18:00)", value)
+	}
+	return t, nil
+}
+
+// parsePublication: data 파일의 publishStatus, publishAt. publishStatus가 없으면 published
+func parsePublication(status, publishAt string) (PublishStatus, time.Time, error) {
+	st := PUBLISH_PUBLISHED
+	if status != "" {
+		st = PublishStatus(status)
+	}
+	valid := false
+	for _, s := range publishStatuses {
+		valid = valid || s == st
+	}
+	if !valid {
+		return "", time.Time{}, fmt.Errorf("publishStatus: %q draft, scheduled, published, archived 중 하나여야 합니다", status)
+	}
+	var t time.Time
+	if publishAt != "" {
+		var err error
+		if t, err = parsePublishAt(publishAt); err != nil {
+			return "", time.Time{}, err
+		}
+	}
+	if st == PUBLISH_SCHEDULED && t.IsZero() {
+		return "", time.Time{}, fmt.Errorf("publishAt: scheduled 업소는 공개 시각이 필요합니다")
+	}
+	return st, t, nil
+}
+
+// isPublicAt: now 시점에 공개중인지
+func (s *Store) isPublicAt(now time.Time) bool {
+	switch s.PublishStatus {
+	case PUBLISH_PUBLISHED, PUBLISH_SCHEDULED:
+		return s.PublishAt.IsZero() || !now.Before(s.PublishAt)
+	}
+	return false
+}
+
+// Archived: 공개 종료
+func (s *Store) Archived() bool { return s.PublishStatus == PUBLISH_ARCHIVED }
+
+// Public: Catalog를 만든 시점에 공개중이었는지. 공개 시각이 지나면 Catalog가 다시 만들어짐
+func (s *Store) Public() bool { return s.public }
+
+// PublicationLabel: ex) 공개, 예약 (2024-03-11 18:00)
+func (s *Store) PublicationLabel() string {
+	if s.public {
+		return PUBLISH_PUBLISHED.Label()
+	}
+	if (s.PublishStatus == PUBLISH_SCHEDULED || s.PublishStatus == PUBLISH_PUBLISHED) && !s.PublishAt.IsZero() {
+		return fmt.Sprintf("%s (%s)", PUBLISH_SCHEDULED.Label(), s.PublishAt.Format(publishAtLayout))
+	}
+	return s.PublishStatus.Label()
+}
@@ -0,0 +1,163 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// 지역 데이터 파일. 스키마는 data/region.schema.json 참고
+const regionFile = "data/region.json"
+
+// 지역 단계
+const (
+	REGION_LEVEL_DO = iota + 1
+	REGION_LEVEL_SI
+	REGION_LEVEL_DONG
+)
+
+// Region: 도, 시, 동 중 하나. 업소의 Location은 data/region.json에 있는 지역이어야 함
+type Region struct {
+	// Name: ex) 강남구
+	Name string `json:"name"`
+	// Short: 제목, 카드에 쓰는 짧은 이름. ex) 강남
+	Short    string    `json:"short"`
+	Children []*Region `json:"children,omitempty"`
+
+	// 아래는 Catalog를 만들때 채워짐
+	Parent *Region `json:"-"`
+	Level  int     `json:"-"`
+	// Stores: 이 지역(하위 지역 포함)의 업소. DatePublished 내림차순
+	Stores []*Store `json:"-"`
+	// Categories: 이 지역의 업종별 업소. 업종 이름순
+	Categories []*Category `json:"-"`
+}
+
+// Do: 이 지역이 속한 도
+func (r *Region) Do() *Region { return r.ancestor(REGION_LEVEL_DO) }
+
+// Si: 이 지역이 속한 시. 도 지역이면 nil
+func (r *Region) Si() *Region { return r.ancestor(REGION_LEVEL_SI) }
+
+func (r *Region) ancestor(level int) *Region {
+	for ; r != nil; r = r.Parent {
+		if r.Level == level {
+			return r
+		}
+	}
+	return nil
+}
+
+// Names: 도부터 이 지역까지의 이름. ex) [서울 강남구 역삼동]
+func (r *Region) Names() []string {
+	var names []string
+	for ; r != nil; r = r.Parent {
+		names = append([]string{r.Name}, names...)
+	}
+	return names
+}
+
+// Path: 지역 허브 페이지 경로. ex) /category/서울/강남구/역삼동
+func (r *Region) Path() string { return "/category/" + strings.Join(r.Names(), "/") }
+
+// DateModified: 이 지역 업소들의 가장 최근 수정일. 업소가 없으면 zero
+func (r *Region) DateModified() time.Time {
+	var t time.Time
+	for _, s := range r.Stores {
+		if s.DateModified.After(t) {
+			t = s.DateModified
+		}
+	}
+	return t
+}
+
+func regionKey(names ...string) string { return strings.Join(names, "/") }
+
+type regionRecord struct {
+	// Schema: 에디터 자동완성용 스키마 경로. 서버에서는 사용하지 않음
+	Schema  string    `json:"$schema,omitempty"`
+	Regions []*Region `json:"regions"`
+}
+
+// linkRegions: Parent, Level을 채우고 이름이 비었거나 같은 단계에 중복된 지역을 에러로 모음
+func linkRegions(field string, parent *Region, list []*Region) []error {
+	var errs []error
+	seen := map[string]bool{}
+	for i, r := range list {
+		f := fmt.Sprintf("%s[%d]", field, i)
+		r.Parent = parent
+		r.Level = REGION_LEVEL_DO
+		if parent != nil {
+			r.Level = parent.Level + 1
+		}
+		if strings.TrimSpace(r.Name) == "" {
+			errs = append(errs, fmt.Errorf("%s.name: 값이 없습니다", f))
+		}
+		if strings.TrimSpace(r.Short) == "" {
+			errs = append(errs, fmt.Errorf("%s.short: 값이 없습니다", f))
+		}
+		if seen[r.Name] {
+			errs = append(errs, fmt.Errorf("%s.name: %q 같은 이름의 지역이 이미 있습니다", f, r.Name))
+		}
+		seen[r.Name] = true
+		// /category/{do}/{si}/{dong} 과 /category/{do}/{si}/{type} 을 구분할 수 있어야 함
+		if r.Level == REGION_LEVEL_DONG && isStoreType(r.Name) {
+			errs = append(errs, fmt.Errorf("%s.name: %q 업종 이름은 동 이름으로 사용할 수 없습니다", f, r.Name))
+		}
+		if r.Level == REGION_LEVEL_DONG && len(r.Children) > 0 {
+			errs = append(errs, fmt.Errorf("%s.children: 동 아래에는 지역을 둘 수 없습니다", f))
+			continue
+		}
+		errs = append(errs, linkRegions(f+".children", r, r.Children)...)
+	}
+	return errs
+}
+
+// loadRegions: 지역 파일을 읽어서 도 목록을 반환
+func loadRegions(path string) ([]*Region, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	r := &regionRecord{}
+	if err := dec.Decode(r); err != nil {
+		return nil, &recordError{Path: path, Err: err}
+	}
+	if errs := linkRegions("regions", nil, r.Regions); len(errs) > 0 {
+		return nil, &recordError{Path: path, Err: errorList(errs)}
+	}
+	return r.Regions, nil
+}
+
+// assignRegions: 업소마다 동 지역을 찾아서 Store.Region에 연결. 없는 지역의 업소는 모두 에러로 모음
+func assignRegions(stores []*Store, regions []*Region) error {
+	byKey := map[string]*Region{}
+	var walk func(list []*Region)
+	walk = func(list []*Region) {
+		for _, r := range list {
+			byKey[regionKey(r.Names()...)] = r
+			walk(r.Children)
+		}
+	}
+	walk(regions)
+
+	var errs errorList
+	for _, s := range stores {
+		l := s.Location
+		r, ok := byKey[regionKey(l.Do, l.Si, l.Dong)]
+		if !ok || r.Level != REGION_LEVEL_DONG {
+			errs = append(errs, &recordError{Path: dataPath(s), Err: fmt.Errorf("location: %s %s %s 지역이 %s 에 없습니다", l.Do, l.Si, l.Dong, regionFile)})
+			continue
+		}
+		s.Region = r
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package store
+
+import (
+	"math"
+	"sort"
+)
+
+// 함께 보면 좋은 업소 점수 가중치
+const (
+	RELATED_WEIGHT_TYPE   = 4.0
+	RELATED_WEIGHT_DONG   = 3.0
+	RELATED_WEIGHT_PRICE  = 2.0
+	RELATED_WEIGHT_RECENT = 1.0
+	// RELATED_RECENT_DAYS: 가장 최근 등록 업소보다 이 일수만큼 먼저 등록되면 최신 점수가 절반
+	RELATED_RECENT_DAYS = 180.0
+)
+
+// RelatedStore: 함께 보면 좋은 업소와 점수
+type RelatedStore struct {
+	Store *Store
+	Score float64
+}
+
+// basePrice: 가격대 비교에 쓰는 대표 주대. 1부 기본 세트, 1부가 없으면 2부 기본 세트
+func basePrice(s *Store) Price {
+	if p := s.Menu.BottlePrice(1); p.Known {
+		return p
+	}
+	return s.Menu.BottlePrice(2)
+}
+
+// priceSimilarity: 두 주대가 비슷할수록 1에 가까움. 하나라도 문의 가격이면 0
+func priceSimilarity(a, b Price) float64 {
+	if !a.Known || !b.Known {
+		return 0
+	}
+	max := math.Max(float64(a.Amount), float64(b.Amount))
+	if max == 0 {
+		return 1
+	}
+	return 1 - math.Abs(float64(a.Amount-b.Amount))/max
+}
+
+// relatedScore: 같은 업종, 같은 동, 비슷한 가격대, 최근 등록일수록 높음.
+// 최신 점수는 현재 시각 대신 newest(Catalog에서 가장 최근 등록일) 기준이므로 같은 Catalog면 항상 같은 점수
+func relatedScore(s, other *Store, newest float64) float64 {
+	score := 0.0
+	if other.Type == s.Type {
+		score += RELATED_WEIGHT_TYPE
+	}
+	if other.Location.Do == s.Location.Do && other.Location.Si == s.Location.Si && other.Location.Dong == s.Location.Dong {
+		score += RELATED_WEIGHT_DONG
+	}
+	score += RELATED_WEIGHT_PRICE * priceSimilarity(basePrice(s), basePrice(other))
+	days := (newest - float64(other.DatePublished.Unix())) / (24 * 60 * 60)
+	score += RELATED_WEIGHT_RECENT * RELATED_RECENT_DAYS / (RELATED_RECENT_DAYS + math.Max(days, 0))
+	return score
+}
+
+// sortRelated: 점수가 높은 순서, 같으면 최근 등록, Slug 순서
+func sortRelated(list []*RelatedStore) {
+	sort.Slice(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if !a.Store.DatePublished.Equal(b.Store.DatePublished) {
+			return a.Store.DatePublished.After(b.Store.DatePublished)
+		}
+		return a.Store.Slug < b.Store.Slug
+	})
+}
+
+// Related: s와 함께 보면 좋은 공개중인 업소. 폐업한 업소와 s는 제외.
+// 점수가 높은 순서, 같으면 최근 등록, Slug 순서
+func (c *Catalog) Related(s *Store, limit int) []*RelatedStore {
+	list := []*RelatedStore{}
+	if len(c.stores) == 0 {
+		return list
+	}
+	// stores는 DatePublished 오름차순
+	newest := float64(c.stores[len(c.stores)-1].DatePublished.Unix())
+	for _, other := range c.stores {
+		if other == s || other.Slug == s.Slug || other.Active.IsPermanentClosed {
+			continue
+		}
+		list = append(list, &RelatedStore{Store: other, Score: relatedScore(s, other, newest)})
+	}
+	sortRelated(list)
+	if limit > 0 && len(list) > limit {
+		list = list[:limit]
+	}
+	return list
+}
@@ -0,0 +1,166 @@
+package store
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var relatedBase = time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+
+// relatedStore: 테스트용 업소. price가 nil이면 문의 가격, days는 relatedBase부터의 등록일
+func relatedStore(slug, storeType, dong string, price *int, days int) *Store {
+	p := Price{}
+	if price != nil {
+		p = Won(*price)
+	}
+	return &Store{
+		Location:      &Location{Do: "서울", Si: "강남구", Dong: dong},
+		Type:          storeType,
+		Title:         slug,
+		Slug:          slug,
+		Active:        &Active{},
+		Menu:          &Menu{Currency: "KRW", Part1: []*Bottle{{Name: "양주 세트", Price: p}}},
+		DatePublished: relatedBase.AddDate(0, 0, days),
+		public:        true,
+	}
+}
+
+func won(n int) *int { return &n }
+
+// relatedCatalog: stores로 만든 Catalog. stores는 DatePublished 오름차순으로 정렬됨
+func relatedCatalog(stores ...*Store) *Catalog {
+	sortStores(stores)
+	return buildIndexes(stores, nil)
+}
+
+func relatedSlugs(list []*RelatedStore) []string {
+	slugs := []string{}
+	for _, r := range list {
+		slugs = append(slugs, r.Store.Slug)
+	}
+	return slugs
+}
+
+func TestRelatedWeightOrder(t *testing.T) {
+	s := relatedStore("base", STORE_TYPE_DOT5, "역삼동", won(150000), 0)
+	c := relatedCatalog(
+		s,
+		// 같은 업종만
+		relatedStore("same-type", STORE_TYPE_DOT5, "논현동", nil, 0),
+		// 같은 동만
+		relatedStore("same-dong", STORE_TYPE_CLUB, "역삼동", nil, 0),
+		// 같은 가격대만
+		relatedStore("same-price", STORE_TYPE_CLUB, "논현동", won(150000), 0),
+		// 가장 최근 등록만
+		relatedStore("recent", STORE_TYPE_CLUB, "논현동", nil, 3650),
+		// 아무것도 같지 않음
+		relatedStore("none", STORE_TYPE_CLUB, "논현동", nil, 0),
+	)
+	got := relatedSlugs(c.Related(s, 0))
+	want := []string{"same-type", "same-dong", "same-price", "recent", "none"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Related() = %v, want %v", got, want)
+	}
+}
+
+func TestRelatedScore(t *testing.T) {
+	s := relatedStore("base", STORE_TYPE_DOT5, "역삼동", won(150000), 0)
+	newest := float64(relatedBase.AddDate(0, 0, 180).Unix())
+	tests := []struct {
+		name  string
+		other *Store
+		want  float64
+	}{
+		{"모두 같음, 최근 등록", relatedStore("a", STORE_TYPE_DOT5, "역삼동", won(150000), 180),
+			RELATED_WEIGHT_TYPE + RELATED_WEIGHT_DONG + RELATED_WEIGHT_PRICE + RELATED_WEIGHT_RECENT},
+		{"다른 시의 같은 동 이름", &Store{
+			Location: &Location{Do: "서울", Si: "서초구", Dong: "역삼동"}, Type: STORE_TYPE_CLUB,
+			Menu: &Menu{}, DatePublished: relatedBase.AddDate(0, 0, 180)}, RELATED_WEIGHT_RECENT},
+		{"주대 절반, 180일 전 등록", relatedStore("b", STORE_TYPE_CLUB, "논현동", won(75000), 0),
+			RELATED_WEIGHT_PRICE*0.5 + RELATED_WEIGHT_RECENT*0.5},
+	}
+	for _, tt := range tests {
+		if got := relatedScore(s, tt.other, newest); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: relatedScore() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSortRelatedTieBreak(t *testing.T) {
+	list := []*RelatedStore{
+		{Store: relatedStore("c", STORE_TYPE_CLUB, "역삼동", nil, 10), Score: 1},
+		{Store: relatedStore("b", STORE_TYPE_CLUB, "역삼동", nil, 20), Score: 1},
+		{Store: relatedStore("a", STORE_TYPE_CLUB, "역삼동", nil, 10), Score: 1},
+		{Store: relatedStore("d", STORE_TYPE_CLUB, "역삼동", nil, 0), Score: 2},
+	}
+	sortRelated(list)
+	// 점수, 최근 등록, Slug 순서
+	want := []string{"d", "b", "a", "c"}
+	if got := relatedSlugs(list); !reflect.DeepEqual(got, want) {
+		t.Fatalf("sortRelated() = %v, want %v", got, want)
+	}
+}
+
+func TestRelatedExcludes(t *testing.T) {
+	s := relatedStore("base", STORE_TYPE_DOT5, "역삼동", won(150000), 0)
+	closed := relatedStore("closed", STORE_TYPE_DOT5, "역삼동", won(150000), 0)
+	closed.Active = &Active{IsPermanentClosed: true, Reason: "정상폐업"}
+	hidden := relatedStore("hidden", STORE_TYPE_DOT5, "역삼동", won(150000), 0)
+	hidden.public = false
+	c := relatedCatalog(s, closed, hidden, relatedStore("open", STORE_TYPE_CLUB, "논현동", nil, 0))
+	want := []string{"open"}
+	if got := relatedSlugs(c.Related(s, 0)); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Related() = %v, want %v", got, want)
+	}
+}
+
+func TestRelatedLimit(t *testing.T) {
+	s := relatedStore("base", STORE_TYPE_DOT5, "역삼동", nil, 0)
+	stores := []*Store{s}
+	for _, slug := range []string{"a", "b", "c", "d", "e"} {
+		stores = append(stores, relatedStore(slug, STORE_TYPE_DOT5, "역삼동", nil, 0))
+	}
+	c := relatedCatalog(stores...)
+	tests := []struct {
+		limit int
+		want  []string
+	}{
+		{0, []string{"a", "b", "c", "d", "e"}},
+		{2, []string{"a", "b"}},
+		{10, []string{"a", "b", "c", "d", "e"}},
+	}
+	for _, tt := range tests {
+		got := relatedSlugs(c.Related(s, tt.limit))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Related(limit=%d) = %v, want %v", tt.limit, got, tt.want)
+		}
+		// 같은 Catalog면 항상 같은 결과
+		if again := relatedSlugs(c.Related(s, tt.limit)); !reflect.DeepEqual(got, again) {
+			t.Errorf("Related(limit=%d) 결과가 호출마다 다릅니다: %v, %v", tt.limit, got, again)
+		}
+	}
+}
+
+func TestPriceSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Price
+		want float64
+	}{
+		{"같은 가격", Won(150000), Won(150000), 1},
+		{"절반", Won(150000), Won(75000), 0.5},
+		{"순서 무관", Won(75000), Won(150000), 0.5},
+		{"하나만 문의", Won(150000), Price{}, 0},
+		{"둘 다 문의", Price{}, Price{}, 0},
+		{"둘 다 0원", Won(0), Won(0), 1},
+		{"하나만 0원", Won(0), Won(150000), 0},
+		{"0원과 문의", Won(0), Price{}, 0},
+	}
+	for _, tt := range tests {
+		if got := priceSimilarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: priceSimilarity(%v, %v) = %v, want %v", tt.name, tt.a, tt.b, got, tt.want)
+		}
+	}
+}
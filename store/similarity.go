@@ -0,0 +1,109 @@
+package store
+
+import (
+	"hash/fnv"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// SHINGLE_SIZE: 유사도를 계산할때 연속된 단어 몇개를 하나로 묶을지
+const SHINGLE_SIZE = 3
+
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// ArticleStat: 소개글 하나의 단어 수
+type ArticleStat struct {
+	Slug  string `json:"slug"`
+	Title string `json:"title"`
+	Path  string `json:"path"`
+	File  string `json:"file"`
+	// Words: 태그를 뺀 단어(어절) 수
+	Words int `json:"words"`
+
+	shingles map[uint64]bool
+}
+
+// SimilarPair: 비슷한 소개글 한쌍
+type SimilarPair struct {
+	A *ArticleStat `json:"a"`
+	B *ArticleStat `json:"b"`
+	// Similarity: 두 소개글 shingle 집합의 Jaccard 유사도. 0 ~ 1
+	Similarity float64 `json:"similarity"`
+}
+
+// DuplicateReport: 짧은 소개글과 비슷한 소개글 목록
+type DuplicateReport struct {
+	MinWords      int            `json:"minWords"`
+	MinSimilarity float64        `json:"minSimilarity"`
+	Articles      int            `json:"articles"`
+	Thin          []*ArticleStat `json:"thin"`
+	Similar       []*SimilarPair `json:"similar"`
+}
+
+// articleWords: 소개글 본문의 단어. 같은 틀로 쓴 소개글을 찾을 수 있도록 상호, 업종, 지역 이름은 같은 단어로 바꿈
+func articleWords(s *Store) []string {
+	text := html.UnescapeString(tagPattern.ReplaceAllString(string(s.Article.HTML), " "))
+	names := []string{s.Title, "{title}", s.Type, "{type}", s.Location.Dong, "{dong}"}
+	if si := s.Region.Si(); si != nil {
+		names = append(names, si.Name, "{si}", si.Short, "{si}")
+	}
+	text = strings.NewReplacer(names...).Replace(text)
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '{' && r != '}'
+	})
+}
+
+func shingles(words []string) map[uint64]bool {
+	set := map[uint64]bool{}
+	for i := 0; i+SHINGLE_SIZE <= len(words); i++ {
+		h := fnv.New64a()
+		h.Write([]byte(strings.Join(words[i:i+SHINGLE_SIZE], " ")))
+		set[h.Sum64()] = true
+	}
+	return set
+}
+
+func jaccard(a, b map[uint64]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	common := 0
+	for k := range a {
+		if b[k] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
+
+// Duplicates: 공개하지 않은 업소까지 모든 소개글 중 minWords 단어보다 짧은 소개글과
+// 유사도가 minSimilarity 이상인 소개글 쌍. 유사도가 높은 순서
+func (c *Catalog) Duplicates(minWords int, minSimilarity float64) *DuplicateReport {
+	r := &DuplicateReport{MinWords: minWords, MinSimilarity: minSimilarity, Thin: []*ArticleStat{}, Similar: []*SimilarPair{}}
+	stats := make([]*ArticleStat, 0, len(c.all))
+	for _, s := range c.all {
+		words := articleWords(s)
+		st := &ArticleStat{Slug: s.Slug, Title: s.Title, Path: s.Path(), File: s.Article.File, Words: len(words), shingles: shingles(words)}
+		stats = append(stats, st)
+		if st.Words < minWords {
+			r.Thin = append(r.Thin, st)
+		}
+	}
+	r.Articles = len(stats)
+	for i, a := range stats {
+		for _, b := range stats[i+1:] {
+			if sim := jaccard(a.shingles, b.shingles); sim >= minSimilarity {
+				r.Similar = append(r.Similar, &SimilarPair{A: a, B: b, Similarity: sim})
+			}
+		}
+	}
+	sort.SliceStable(r.Thin, func(i, j int) bool { return r.Thin[i].Words < r.Thin[j].Words })
+	sort.SliceStable(r.Similar, func(i, j int) bool { return r.Similar[i].Similarity > r.Similar[j].Similarity })
+	return r
+}
@@ -0,0 +1,120 @@
+package store
+
+import (
+	"html/template"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// similarityTemplate: 강남구 역삼동 에이원 쩜오의 소개글
+const similarityTemplate = `<h2>강남 에이원 쩜오</h2>
+<p>강남 에이원 쩜오는 역삼동에서 가장 인기있는 쩜오입니다. 에이원은 넓은 룸과 친절한 서비스로 유명합니다.</p>
+<p>강남구 역삼동 에이원 쩜오 예약은 전화로 문의 주세요. 강남 에이원에서 즐거운 시간 보내세요.</p>`
+
+// similarityRewritten: similarityTemplate에서 상호, 업종, 동만 바꾼 강남구 논현동 유앤미 가라오케의 소개글
+const similarityRewritten = `<h2>강남 유앤미 가라오케</h2>
+<p>강남 유앤미 가라오케는 논현동에서 가장 인기있는 가라오케입니다. 유앤미은 넓은 룸과 친절한 서비스로 유명합니다.</p>
+<p>강남구 논현동 유앤미 가라오케 예약은 전화로 문의 주세요. 강남 유앤미에서 즐거운 시간 보내세요.</p>`
+
+// similarityStore: 강남구 dong에 있는 업소. html이 소개글
+func similarityStore(slug, storeType, title, dong, html string) *Store {
+	si := &Region{Name: "강남구", Short: "강남", Level: REGION_LEVEL_SI}
+	return &Store{
+		Location: &Location{Do: "서울", Si: "강남구", Dong: dong},
+		Region:   &Region{Name: dong, Level: REGION_LEVEL_DONG, Parent: si},
+		Type:     storeType,
+		Title:    title,
+		Slug:     slug,
+		Active:   &Active{},
+		Article:  &Article{File: slug + ".html", HTML: template.HTML(html)},
+		public:   true,
+	}
+}
+
+func TestArticleWordsNormalized(t *testing.T) {
+	a := similarityStore("a", STORE_TYPE_DOT5, "에이원", "역삼동", similarityTemplate)
+	// 상호, 업종, 동만 바꿔서 쓴 소개글
+	b := similarityStore("b", STORE_TYPE_KARAOKE, "유앤미", "논현동", similarityRewritten)
+	wa, wb := articleWords(a), articleWords(b)
+	if !reflect.DeepEqual(wa, wb) {
+		t.Fatalf("articleWords()가 다릅니다:\n%v\n%v", wa, wb)
+	}
+	if sim := jaccard(shingles(wa), shingles(wb)); sim != 1 {
+		t.Errorf("jaccard() = %v, want 1", sim)
+	}
+}
+
+func TestJaccard(t *testing.T) {
+	words := []string{"a", "b", "c", "d", "e"}
+	tests := []struct {
+		name string
+		a, b []string
+		want float64
+	}{
+		{"같음", words, words, 1},
+		{"둘 다 비어있음", nil, nil, 0},
+		{"하나만 비어있음", words, nil, 0},
+		// shingle이 하나도 만들어지지 않을 만큼 짧음
+		{"짧은 글", []string{"a", "b"}, []string{"a", "b"}, 0},
+		// abc bcd cde / abc bcd cdf. 공통 2개, 전체 4개
+		{"일부", words, []string{"a", "b", "c", "d", "f"}, 0.5},
+		{"다름", words, []string{"v", "w", "x", "y", "z"}, 0},
+	}
+	for _, tt := range tests {
+		if got := jaccard(shingles(tt.a), shingles(tt.b)); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: jaccard() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDuplicates(t *testing.T) {
+	a := similarityStore("a", STORE_TYPE_DOT5, "에이원", "역삼동", similarityTemplate)
+	b := similarityStore("b", STORE_TYPE_KARAOKE, "유앤미", "논현동", similarityRewritten)
+	other := similarityStore("other", STORE_TYPE_CLUB, "오키도키", "삼성동",
+		"<p>오키도키는 삼성동 코엑스 근처의 클럽으로 주말마다 유명 DJ 공연이 열립니다. 입장료와 테이블 가격은 홈페이지를 참고하세요.</p>")
+	empty := similarityStore("empty", STORE_TYPE_DOT5, "빈글", "역삼동", "")
+	empty2 := similarityStore("empty2", STORE_TYPE_DOT5, "빈글2", "역삼동", "<p></p>")
+	// 공개하지 않은 업소도 검사
+	hidden := similarityStore("hidden", STORE_TYPE_DOT5, "숨김", "역삼동", "<p>짧은 소개글</p>")
+	hidden.public = false
+	c := buildIndexes([]*Store{a, b, other, empty, empty2, hidden}, nil)
+
+	r := c.Duplicates(10, 0.8)
+	if r.Articles != 6 {
+		t.Errorf("Articles = %d, want 6", r.Articles)
+	}
+	thin := []string{}
+	for _, st := range r.Thin {
+		thin = append(thin, st.Slug)
+	}
+	// 짧은 순서
+	if want := []string{"empty", "empty2", "hidden"}; !reflect.DeepEqual(thin, want) {
+		t.Errorf("Thin = %v, want %v", thin, want)
+	}
+	// 빈 소개글끼리는 비슷한 쌍이 아님
+	if len(r.Similar) != 1 {
+		t.Fatalf("Similar = %d쌍, want 1쌍", len(r.Similar))
+	}
+	if p := r.Similar[0]; p.A.Slug != "a" || p.B.Slug != "b" || p.Similarity != 1 {
+		t.Errorf("Similar[0] = %s, %s %v, want a, b 1", p.A.Slug, p.B.Slug, p.Similarity)
+	}
+
+	// 기준 단어 수와 같으면 짧은 소개글이 아님
+	words := len(articleWords(other))
+	if r := c.Duplicates(words, 1); containsThin(r, "other") {
+		t.Errorf("minWords=%d: other(%d 단어)가 짧은 소개글입니다", words, words)
+	}
+	if r := c.Duplicates(words+1, 1); !containsThin(r, "other") {
+		t.Errorf("minWords=%d: other(%d 단어)가 짧은 소개글이 아닙니다", words+1, words)
+	}
+}
+
+func containsThin(r *DuplicateReport, slug string) bool {
+	for _, st := range r.Thin {
+		if st.Slug == slug {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package store
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// dataPath: 업소의 data 파일 경로. 에러 메시지에서 업소를 식별하는데 사용
+func dataPath(s *Store) string {
+	return filepath.Join(dataDir, s.Location.Do, s.Location.Si, s.Location.Dong, s.Type, s.Title+".json")
+}
+
+func validatePrice(field string, p Price) []error {
+	if p.Known && p.Amount < 0 {
+		return []error{fmt.Errorf("%s: 가격은 음수일 수 없습니다 (%d)", field, p.Amount)}
+	}
+	return nil
+}
+
+func validateCharge(field string, c *Charge) []error {
+	if c == nil {
+		return []error{fmt.Errorf("%s: 값이 없습니다", field)}
+	}
+	errs := validatePrice(field+".price", c.Price)
+	if c.Per != PER_PERSON && c.Per != PER_ROOM {
+		errs = append(errs, fmt.Errorf("%s.per: %q person 또는 room 이어야 합니다", field, c.Per))
+	}
+	return errs
+}
+
+func validateMenu(m *Menu) []error {
+	var errs []error
+	if m.Currency != "KRW" {
+		errs = append(errs, fmt.Errorf("menu.currency: %q 현재는 KRW만 지원합니다", m.Currency))
+	}
+	if m.VAT != "" && m.VAT != VAT_INCLUDED && m.VAT != VAT_EXCLUDED {
+		errs = append(errs, fmt.Errorf("menu.vat: %q included 또는 excluded 이어야 합니다", m.VAT))
+	}
+	for part := 1; part <= 2; part++ {
+		for i, b := range m.Bottles(part) {
+			field := fmt.Sprintf("menu.part%d[%d]", part, i)
+			if b.Name == "" {
+				errs = append(errs, fmt.Errorf("%s.name: 값이 없습니다", field))
+			}
+			errs = append(errs, validatePrice(field+".price", b.Price)...)
+		}
+	}
+	errs = append(errs, validateCharge("menu.tc", m.TC)...)
+	errs = append(errs, validateCharge("menu.rt", m.RT)...)
+	if m.Weekend != nil {
+		if len(m.Weekend.Days) == 0 {
+			errs = append(errs, fmt.Errorf("menu.weekend.days: 값이 없습니다"))
+		}
+		errs = append(errs, validateCharge("menu.weekend", &m.Weekend.Charge)...)
+	}
+	return errs
+}
+
+func validateStore(s *Store) (errs, warnings []error) {
+	if strings.TrimSpace(s.Title) == "" {
+		errs = append(errs, fmt.Errorf("title: 값이 없습니다"))
+	}
+	for _, slug := range append([]string{s.Slug}, s.FormerSlugs...) {
+		if !slugPattern.MatchString(slug) {
+			errs = append(errs, fmt.Errorf("slug: %q 영문 소문자, 숫자, '-'만 사용할 수 있습니다", slug))
+		}
+	}
+	if s.Hour != nil {
+		if err := s.Hour.compile(); err != nil {
+			errs = append(errs, &recordError{Path: "hour", Err: err})
+		}
+	}
+	if s.Menu != nil {
+		errs = append(errs, validateMenu(s.Menu)...)
+	}
+	if s.Location.Geo != nil {
+		errs = append(errs, validateGeo(s.Location.Geo)...)
+	}
+	if s.Active != nil && s.Active.IsPermanentClosed && strings.TrimSpace(s.Active.Reason) == "" {
+		errs = append(errs, fmt.Errorf("active.reason: 폐업 업소는 폐업사유가 필요합니다"))
+	}
+	names := map[string]bool{}
+	for i, img := range s.Gallery {
+		if err := img.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("gallery[%d].%s", i, err))
+			continue
+		}
+		if names[img.Name] {
+			errs = append(errs, fmt.Errorf("gallery[%d].name: %q 이미 있는 이미지입니다", i, img.Name))
+		}
+		names[img.Name] = true
+	}
+	var prev time.Time
+	for i, r := range s.revisions {
+		field := fmt.Sprintf("history[%d]", i)
+		effective, err := parseDate(field+".effective", r.Effective)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if effective.Before(s.DatePublished) {
+			warnings = append(warnings, fmt.Errorf("%s.effective(%s)가 datePublished(%s)보다 이릅니다",
+				field, r.Effective, s.DatePublished.Format(dateLayout)))
+		}
+		if effective.Before(prev) {
+			errs = append(errs, fmt.Errorf("%s.effective: 적용일 순서대로 입력해야 합니다", field))
+		}
+		prev = effective
+		if r.Menu != nil {
+			for _, err := range validateMenu(r.Menu) {
+				errs = append(errs, fmt.Errorf("%s.%s", field, err))
+			}
+		}
+		if r.Hour != nil {
+			if err := r.Hour.compile(); err != nil {
+				errs = append(errs, &recordError{Path: field + ".hour", Err: err})
+			}
+		}
+		if r.Active != nil && r.Active.IsPermanentClosed && strings.TrimSpace(r.Active.Reason) == "" {
+			errs = append(errs, fmt.Errorf("%s.active.reason: 폐업 업소는 폐업사유가 필요합니다", field))
+		}
+	}
+	return errs, warnings
+}
+
+// Validate: 모든 업소의 데이터 오류를 모아서 반환. 경고는 로그로만 남기고 에러로 취급하지 않음
+func Validate(stores []*Store) error {
+	var errs errorList
+	seen := map[storeKey]bool{}
+	slugs := map[string]*Store{}
+	formerPaths := map[string]*Store{}
+	for _, s := range stores {
+		l := s.Location
+		k := storeKey{l.Do, l.Si, l.Dong, s.Type, s.Title}
+		if seen[k] {
+			errs = append(errs, &recordError{Path: dataPath(s), Err: fmt.Errorf("같은 지역, 업종, 상호의 업소가 이미 있습니다")})
+		}
+		seen[k] = true
+		for _, slug := range append([]string{s.Slug}, s.FormerSlugs...) {
+			if other, ok := slugs[slug]; ok {
+				errs = append(errs, &recordError{Path: dataPath(s), Err: fmt.Errorf("slug: %q 는 %s 에서 이미 사용중입니다", slug, dataPath(other))})
+				continue
+			}
+			slugs[slug] = s
+		}
+		for _, p := range s.FormerPaths {
+			if other, ok := formerPaths[p]; ok && other != s {
+				errs = append(errs, &recordError{Path: dataPath(s), Err: fmt.Errorf("formerPaths: %q 는 %s 에서 이미 사용중입니다", p, dataPath(other))})
+				continue
+			}
+			formerPaths[p] = s
+		}
+
+		storeErrs, warnings := validateStore(s)
+		if len(storeErrs) > 0 {
+			errs = append(errs, &recordError{Path: dataPath(s), Err: errorList(storeErrs)})
+		}
+		for _, w := range warnings {
+			log.Printf("store: warning: %s", &recordError{Path: dataPath(s), Err: w})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
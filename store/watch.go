@@ -0,0 +1,124 @@
+package store
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+var reloadMu sync.Mutex
+
+// Reload: data 디렉토리를 다시 읽어 Catalog를 교체. 에러가 있으면 기존 Catalog를 유지
+func Reload() error {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	return reload()
+}
+
+// reload: reloadMu를 잡은 상태에서 호출해야 함
+func reload() error {
+	regions, err := loadRegions(regionFile)
+	if err != nil {
+		return err
+	}
+	list, err := loadStores(dataDir)
+	if err != nil {
+		return err
+	}
+	if err := Validate(list); err != nil {
+		return err
+	}
+	if err := assignRegions(list, regions); err != nil {
+		return err
+	}
+	c, err := newCatalog(list, regions)
+	if err != nil {
+		return err
+	}
+	current.Store(c)
+	return nil
+}
+
+// fingerprint: paths(디렉토리는 하위 파일 전체)의 경로, 크기, 수정시간으로 만든 해시. 값이 바뀌면 데이터가 변경된 것
+func fingerprint(paths ...string) (uint64, error) {
+	h := fnv.New64a()
+	for _, p := range paths {
+		if err := fingerprintPath(h, p); err != nil {
+			return 0, err
+		}
+	}
+	return h.Sum64(), nil
+}
+
+func fingerprintPath(w io.Writer, root string) error {
+	return filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s:%d:%d\n", path, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+}
+
+// watcher: Watch의 상태
+type watcher struct {
+	paths  []string
+	reload func() error
+	// last: 마지막으로 확인한 fingerprint. reload 후에는 reload가 만든 소개글 파일까지 포함한 값
+	last uint64
+	// failed: reload에 실패한 fingerprint. 같은 데이터로 다시 실패하면 로그를 남기지 않음
+	failed uint64
+}
+
+// check: 데이터가 바뀌었거나 Catalog를 다시 만들 시각이 지났으면 reload
+func (w *watcher) check(stale bool) {
+	fp, err := fingerprint(w.paths...)
+	if err != nil {
+		log.Printf("store: watch %s: %s", dataDir, err)
+		return
+	}
+	if fp == w.last && !stale {
+		return
+	}
+	err = w.reload()
+	// reload 중에 만든 소개글 파일(createHTMLFiles)로 다시 reload 하지 않도록 reload 후의 값을 기록
+	if after, ferr := fingerprint(w.paths...); ferr == nil {
+		fp = after
+	}
+	w.last = fp
+	if err != nil {
+		if fp != w.failed {
+			log.Printf("store: reload failed, keeping last good catalog:\n%s", err)
+			w.failed = fp
+		}
+		return
+	}
+	w.failed = 0
+	log.Printf("store: reloaded %d stores", len(ListAllStores()))
+}
+
+// Watch: interval마다 data 디렉토리, 지역 파일, 소개글 디렉토리를 확인하고 변경되었으면 Reload. 반환하지 않으므로 고루틴으로 실행.
+// reload에 실패하면 데이터가 바뀔때까지 같은 에러는 다시 로그를 남기지 않음
+func Watch(interval time.Duration) {
+	w := &watcher{paths: []string{dataDir, regionFile, articleDir}, reload: Reload}
+	last, err := fingerprint(w.paths...)
+	if err != nil {
+		log.Printf("store: watch %s: %s", dataDir, err)
+	}
+	w.last = last
+	for range time.Tick(interval) {
+		w.check(Current().Stale(time.Now()))
+	}
+}
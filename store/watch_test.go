@@ -0,0 +1,83 @@
+package store
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	data := filepath.Join(dir, "store.json")
+	if err := os.WriteFile(data, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+	prev := current.Load()
+	current.Store(&Catalog{})
+	defer current.Store(prev)
+
+	reloads := 0
+	var reloadErr error
+	w := &watcher{paths: []string{dir}, reload: func() error {
+		reloads++
+		// createHTMLFiles처럼 reload 중에 감시하는 디렉토리에 없는 파일을 만듬
+		generated := filepath.Join(dir, "generated.html")
+		if _, err := os.Stat(generated); err == nil {
+			return reloadErr
+		}
+		if err := os.WriteFile(generated, []byte(ARTICLE_PLACEHOLDER), 0644); err != nil {
+			return err
+		}
+		return reloadErr
+	}}
+	last, err := fingerprint(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.last = last
+	// 수정시간 해상도와 관계없이 바뀌도록 매번 길이가 다른 내용
+	change := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(data, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		name    string
+		do      func()
+		stale   bool
+		reloads int
+		// logs: 지금까지 남은 reload 실패 로그 수
+		logs int
+	}{
+		{"변경 없음", func() {}, false, 0, 0},
+		{"데이터 변경", func() { change(`{"a":1}`) }, false, 1, 0},
+		// reload가 만든 파일로는 다시 reload 하지 않음
+		{"reload가 만든 파일", func() {}, false, 1, 0},
+		{"다시 만들 시각", func() {}, true, 2, 0},
+		{"실패", func() { reloadErr = errors.New("bad data"); change(`{"ab":2}`) }, false, 3, 1},
+		// 같은 데이터로 다시 실패하면 로그를 남기지 않음
+		{"같은 데이터로 실패", func() {}, true, 4, 1},
+		{"같은 데이터로 또 실패", func() {}, true, 5, 1},
+		{"고쳤지만 다시 실패", func() { change(`{"abc":3}`) }, false, 6, 2},
+		{"성공", func() { reloadErr = nil; change(`{"abcd":4}`) }, false, 7, 2},
+		{"변경 없음", func() {}, false, 7, 2},
+	}
+	for _, tt := range tests {
+		tt.do()
+		w.check(tt.stale)
+		if reloads != tt.reloads {
+			t.Errorf("%s: reload %d번, want %d번", tt.name, reloads, tt.reloads)
+		}
+		if got := strings.Count(logs.String(), "reload failed"); got != tt.logs {
+			t.Errorf("%s: 실패 로그 %d개, want %d개", tt.name, got, tt.logs)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Record: s의 data 파일 내용의 복사본. 수정해도 s에는 영향 없음
+func (s *Store) Record() (*Record, error) {
+	b, err := json.Marshal(s.record)
+	if err != nil {
+		return nil, err
+	}
+	r := &Record{}
+	if err := json.Unmarshal(b, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// definedAt: t 시점에 적용중인 값을 정한 History의 index. -1이면 등록시점의 값(Record 본문)
+func (r *Record) definedAt(t time.Time, has func(rev *Revision) bool) int {
+	idx := -1
+	for i, rev := range r.History {
+		effective, err := parseDate("effective", rev.Effective)
+		if err != nil || effective.After(t) {
+			continue
+		}
+		if has(rev) {
+			idx = i
+		}
+	}
+	return idx
+}
+
+func hasMenu(rev *Revision) bool   { return rev.Menu != nil }
+func hasHour(rev *Revision) bool   { return rev.Hour != nil }
+func hasActive(rev *Revision) bool { return rev.Active != nil }
+
+// At: t 시점에 적용중인 메뉴, 영업시간, 영업상태
+func (r *Record) At(t time.Time) (menu *Menu, hour *Hour, active *Active) {
+	menu, hour, active = r.Menu, r.Hour, r.Active
+	if i := r.definedAt(t, hasMenu); i >= 0 {
+		menu = r.History[i].Menu
+	}
+	if i := r.definedAt(t, hasHour); i >= 0 {
+		hour = r.History[i].Hour
+	}
+	if i := r.definedAt(t, hasActive); i >= 0 {
+		active = r.History[i].Active
+	}
+	return menu, hour, active
+}
+
+// Amend: t 시점에 적용중인 값을 이력을 남기지 않고 고침(오타 수정 등).
+// 그 값을 정한 Revision이 있으면 Revision을, 없으면 등록시점의 값을 고침. nil인 항목은 그대로
+func (r *Record) Amend(t time.Time, menu *Menu, hour *Hour, active *Active) {
+	if menu != nil {
+		if i := r.definedAt(t, hasMenu); i >= 0 {
+			r.History[i].Menu = menu
+		} else {
+			r.Menu = menu
+		}
+	}
+	if hour != nil {
+		if i := r.definedAt(t, hasHour); i >= 0 {
+			r.History[i].Hour = hour
+		} else {
+			r.Hour = hour
+		}
+	}
+	if active != nil {
+		if i := r.definedAt(t, hasActive); i >= 0 {
+			r.History[i].Active = active
+		} else {
+			r.Active = active
+		}
+	}
+}
+
+func sameJSON(a, b interface{}) bool {
+	x, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	y, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(x, y)
+}
+
+// Revise: effective 날짜부터 적용되는 Revision을 History에 추가. 그 날짜에 적용중인 값과 같거나 nil인 항목은 제외.
+// 바뀐 항목이 없으면 추가하지 않고 false
+func (r *Record) Revise(effective, note string, menu *Menu, hour *Hour, active *Active) (bool, error) {
+	t, err := parseDate("effective", effective)
+	if err != nil {
+		return false, err
+	}
+	curMenu, curHour, curActive := r.At(t)
+	rev := &Revision{Effective: effective, Note: note}
+	if menu != nil && !sameJSON(menu, curMenu) {
+		rev.Menu = menu
+	}
+	if hour != nil && !sameJSON(hour, curHour) {
+		rev.Hour = hour
+	}
+	if active != nil && !sameJSON(active, curActive) {
+		rev.Active = active
+	}
+	if rev.Menu == nil && rev.Hour == nil && rev.Active == nil {
+		return false, nil
+	}
+	// 적용일 순서를 유지. 같은 날짜면 뒤에 추가
+	i := len(r.History)
+	for i > 0 {
+		prev, err := parseDate("effective", r.History[i-1].Effective)
+		if err == nil && !prev.After(t) {
+			break
+		}
+		i--
+	}
+	r.History = append(r.History, nil)
+	copy(r.History[i+1:], r.History[i:])
+	r.History[i] = rev
+	return true, nil
+}
+
+// rememberPath: 위치, 업종, 상호가 prev와 다르면 prev의 이전 주소 형식 경로를 FormerPaths에 추가해서
+// 이전 주소로 들어온 요청이 리다이렉트 되도록 함. 다시 이전 값으로 바꾸면 FormerPaths에서 뺌
+func (r *Record) rememberPath(prev *Store) {
+	if r.Location == nil {
+		return
+	}
+	current := storeKey{r.Location.Do, r.Location.Si, r.Location.Dong, r.Type, r.Title}.legacyPath()
+	r.FormerPaths = remember(r.FormerPaths, current, keyOf(prev).legacyPath())
+}
+
+// rememberSlug: slug가 prev와 다르면 prev의 slug를 FormerSlugs에 추가. 다시 이전 slug로 바꾸면 FormerSlugs에서 뺌
+func (r *Record) rememberSlug(prev *Store) {
+	r.FormerSlugs = remember(r.FormerSlugs, r.Slug, prev.Slug)
+}
+
+// remember: former에서 current를 빼고, old가 current와 다르면 추가. 비어있으면 nil
+func remember(former []string, current, old string) []string {
+	list := make([]string, 0, len(former)+1)
+	for _, v := range former {
+		if v != current && v != old {
+			list = append(list, v)
+		}
+	}
+	if old != current {
+		list = append(list, old)
+	}
+	if len(list) == 0 {
+		return nil
+	}
+	return list
+}
+
+// relPath: dataDir 기준 파일 경로. location, type, title이 없으면 빈 문자열
+func (r *Record) relPath() string {
+	if r.Location == nil || r.Type == "" || r.Title == "" {
+		return ""
+	}
+	return filepath.Join(r.Location.Do, r.Location.Si, r.Location.Dong, r.Type, r.Title+".json")
+}
+
+func encodeRecord(r *Record) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "\t")
+	if err := enc.Encode(r); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeFile: 임시 파일에 쓰고 rename. 쓰는 도중에 Watch가 반쯤 쓴 파일을 읽지 않도록
+func writeFile(path string, b []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// assetPaths: 업소 소개글 파일(Markdown, HTML)과 이미지 디렉토리. 위치, 업종, 상호가 바뀌면 함께 옮김
+func assetPaths(r *Record) []string {
+	l := r.Location
+	return []string{
+		articleFile(l, r.Type, r.Title, ".md"),
+		articleFile(l, r.Type, r.Title, ".html"),
+		fmt.Sprintf("static/img/store/%s/%s/%s/%s/%s", l.Do, l.Si, l.Dong, r.Type, r.Title),
+	}
+}
+
+// moveAssets: from의 소개글, 이미지를 to로 옮김. from에 없거나 to에 이미 있으면 옮기지 않음. 옮긴 경로 쌍을 반환
+func moveAssets(from, to *Record) [][2]string {
+	var moved [][2]string
+	dst := assetPaths(to)
+	for i, src := range assetPaths(from) {
+		if _, err := os.Stat(src); err != nil {
+			continue
+		}
+		if _, err := os.Stat(dst[i]); err == nil {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(dst[i]), os.ModePerm); err != nil {
+			continue
+		}
+		if err := os.Rename(src, dst[i]); err == nil {
+			moved = append(moved, [2]string{src, dst[i]})
+		}
+	}
+	return moved
+}
+
+// Save: r을 data 파일로 저장하고 Catalog를 다시 만듬. prev가 nil이 아니면 prev의 data 파일을 대체함.
+// 위치, 업종, 상호가 바뀌면 이전 data 파일은 지우고 소개글, 이미지는 새 경로로 옮김.
+// slug, 위치, 업종, 상호가 바뀌면 이전 값은 FormerSlugs, FormerPaths에 남겨서 리다이렉트 되도록 함.
+// 다시 만든 Catalog에 에러가 있으면 파일을 모두 되돌리고 에러를 반환
+func Save(r *Record, prev *Store) error {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	if prev != nil {
+		r.rememberSlug(prev)
+		r.rememberPath(prev)
+	}
+	rel := r.relPath()
+	s, err := r.toStore(rel)
+	if err != nil {
+		return err
+	}
+	if errs, _ := validateStore(s); len(errs) > 0 {
+		return errorList(errs)
+	}
+	// 없는 지역이면 파일을 쓰기 전에 에러. 빈 디렉토리가 남지 않도록
+	if err := assignRegions([]*Store{s}, current.Load().regions); err != nil {
+		return err
+	}
+	b, err := encodeRecord(r)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dataDir, rel)
+	oldPath := ""
+	var old []byte
+	if prev != nil {
+		oldPath = dataPath(prev)
+		if old, err = os.ReadFile(oldPath); err != nil {
+			return err
+		}
+	}
+	if path != oldPath {
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s: 같은 지역, 업종, 상호의 업소가 이미 있습니다", path)
+		}
+	}
+
+	if err := writeFile(path, b); err != nil {
+		return err
+	}
+	var moved [][2]string
+	if prev != nil && path != oldPath {
+		if err := os.Remove(oldPath); err != nil {
+			os.Remove(path)
+			return err
+		}
+		moved = moveAssets(prev.record, r)
+	}
+	if err := reload(); err != nil {
+		// 되돌리기
+		for _, m := range moved {
+			os.Rename(m[1], m[0])
+		}
+		if prev != nil {
+			if path != oldPath {
+				os.Remove(path)
+			}
+			writeFile(oldPath, old)
+		} else {
+			os.Remove(path)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRememberPath(t *testing.T) {
+	prev := &Store{Location: &Location{Do: "서울", Si: "강남구", Dong: "역삼동"}, Type: STORE_TYPE_DOT5, Title: "831"}
+	tests := []struct {
+		name   string
+		dong   string
+		title  string
+		former []string
+		want   []string
+	}{
+		{"그대로", "역삼동", "831", nil, nil},
+		{"상호 변경", "역삼동", "832", nil, []string{"서울/강남구/역삼동/쩜오/831"}},
+		{"이미 있음", "역삼동", "832", []string{"서울/강남구/역삼동/쩜오/831"}, []string{"서울/강남구/역삼동/쩜오/831"}},
+		// 이전 값으로 되돌리면 현재 주소는 FormerPaths에서 빠짐
+		{"되돌림", "논현동", "831", []string{"서울/강남구/논현동/쩜오/831"}, []string{"서울/강남구/역삼동/쩜오/831"}},
+	}
+	for _, tt := range tests {
+		r := &Record{
+			Location:    &Location{Do: "서울", Si: "강남구", Dong: tt.dong},
+			Type:        STORE_TYPE_DOT5,
+			Title:       tt.title,
+			FormerPaths: tt.former,
+		}
+		r.rememberPath(prev)
+		if !reflect.DeepEqual(r.FormerPaths, tt.want) {
+			t.Errorf("%s: FormerPaths = %v, want %v", tt.name, r.FormerPaths, tt.want)
+		}
+	}
+}
+
+func TestRememberSlug(t *testing.T) {
+	tests := []struct {
+		name   string
+		prev   string
+		slug   string
+		former []string
+		want   []string
+	}{
+		{"그대로", "a", "a", nil, nil},
+		{"변경", "a", "b", nil, []string{"a"}},
+		{"이미 있음", "a", "b", []string{"a"}, []string{"a"}},
+		{"여러번 변경", "a", "c", []string{"x"}, []string{"x", "a"}},
+		// a -> b -> a. 현재 slug는 FormerSlugs에서 빠짐
+		{"되돌림", "b", "a", []string{"a"}, []string{"b"}},
+	}
+	for _, tt := range tests {
+		r := &Record{Slug: tt.slug, FormerSlugs: tt.former}
+		r.rememberSlug(&Store{Slug: tt.prev})
+		if !reflect.DeepEqual(r.FormerSlugs, tt.want) {
+			t.Errorf("%s: FormerSlugs = %v, want %v", tt.name, r.FormerSlugs, tt.want)
+		}
+	}
+}
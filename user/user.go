@@ -0,0 +1,268 @@
+package user
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// 관리자 계정 파일. 비밀번호 해시가 들어있으므로 저장소에 올리지 않음(.gitignore)
+const dataFile = "data/user/users.json"
+
+// Role: 권한
+type Role string
+
+const (
+	// ROLE_EDITOR: 업소 등록, 수정, 폐업 처리
+	ROLE_EDITOR Role = "editor"
+	// ROLE_ADMIN: editor 권한과 계정 관리
+	ROLE_ADMIN Role = "admin"
+)
+
+// Label: ex) 관리자
+func (r Role) Label() string {
+	switch r {
+	case ROLE_EDITOR:
+		return "편집자"
+	case ROLE_ADMIN:
+		return "관리자"
+	}
+	return string(r)
+}
+
+func ParseRole(v string) (Role, error) {
+	switch Role(v) {
+	case ROLE_EDITOR, ROLE_ADMIN:
+		return Role(v), nil
+	}
+	return "", fmt.Errorf("role: %q editor 또는 admin 이어야 합니다", v)
+}
+
+// 비밀번호 최소 길이
+const passwordMinLength = 10
+
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{2,31}$`)
+
+type User struct {
+	Name string `json:"name"`
+	// PasswordHash: bcrypt 해시
+	PasswordHash string    `json:"passwordHash"`
+	Role         Role      `json:"role"`
+	DateCreated  time.Time `json:"dateCreated"`
+	// DateCredentialsChanged: 비밀번호나 권한을 바꾼 시각. 그 전에 로그인한 세션은 다시 로그인해야 함
+	DateCredentialsChanged time.Time `json:"dateCredentialsChanged"`
+}
+
+// IsSessionValid: created에 로그인한 세션을 계속 쓸 수 있는지. 그 후에 비밀번호나 권한을 바꿨으면 false
+func (u *User) IsSessionValid(created time.Time) bool {
+	return !created.Before(u.DateCredentialsChanged)
+}
+
+// IsAdmin: 계정 관리 권한
+func (u *User) IsAdmin() bool { return u.Role == ROLE_ADMIN }
+
+var (
+	mu    sync.RWMutex
+	users = map[string]*User{}
+	// dummyHash: 없는 계정으로 로그인할때도 비교 시간을 같게 하기 위한 해시
+	dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+)
+
+var ErrInvalidLogin = errors.New("아이디 또는 비밀번호가 올바르지 않습니다")
+
+// Init: 계정 파일을 읽음. 파일이 없으면 계정 없이 시작
+func Init() error {
+	mu.Lock()
+	defer mu.Unlock()
+	b, err := os.ReadFile(dataFile)
+	if errors.Is(err, os.ErrNotExist) {
+		users = map[string]*User{}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	list := []*User{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&list); err != nil {
+		return fmt.Errorf("%s: %s", dataFile, err)
+	}
+	m := make(map[string]*User, len(list))
+	for _, u := range list {
+		m[u.Name] = u
+	}
+	users = m
+	return nil
+}
+
+// save: mu를 잡은 상태에서 호출해야 함
+func save() error {
+	list := make([]*User, 0, len(users))
+	for _, u := range users {
+		list = append(list, u)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	b, err := json.MarshalIndent(list, "", "\t")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dataFile), 0700); err != nil {
+		return err
+	}
+	tmp := dataFile + ".tmp"
+	if err := os.WriteFile(tmp, append(b, '\n'), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, dataFile)
+}
+
+func hashPassword(password string) (string, error) {
+	if len([]rune(password)) < passwordMinLength {
+		return "", fmt.Errorf("password: %d자 이상이어야 합니다", passwordMinLength)
+	}
+	h, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(h), nil
+}
+
+func Get(name string) (u *User, has bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	u, has = users[name]
+	return u, has
+}
+
+// List: 이름순
+func List() []*User {
+	mu.RLock()
+	defer mu.RUnlock()
+	list := make([]*User, 0, len(users))
+	for _, u := range users {
+		list = append(list, u)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// ValidateName: 영문 소문자, 숫자, '.', '_', '-' 3~32자
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("name: %q 영문 소문자, 숫자, '.', '_', '-' 3~32자여야 합니다", name)
+	}
+	return nil
+}
+
+func Add(name, password string, role Role) error {
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+	if _, err := ParseRole(string(role)); err != nil {
+		return err
+	}
+	h, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if _, has := users[name]; has {
+		return fmt.Errorf("name: %q 이미 있는 계정입니다", name)
+	}
+	// fiber의 c.FormValue 등은 요청이 끝나면 바뀌는 버퍼를 가리키므로 저장하는 문자열은 복사
+	name, role = strings.Clone(name), Role(strings.Clone(string(role)))
+	users[name] = &User{Name: name, PasswordHash: h, Role: role, DateCreated: time.Now()}
+	if err := save(); err != nil {
+		delete(users, name)
+		return err
+	}
+	return nil
+}
+
+// update: 계정을 복사해서 f로 수정한 뒤 저장. 저장에 실패하면 원래대로
+func update(name string, f func(u *User) error) error {
+	mu.Lock()
+	defer mu.Unlock()
+	old, has := users[name]
+	if !has {
+		return fmt.Errorf("name: %q 없는 계정입니다", name)
+	}
+	u := *old
+	if err := f(&u); err != nil {
+		return err
+	}
+	// name은 요청 버퍼를 가리킬 수 있으므로 저장된 이름을 키로 사용
+	users[old.Name] = &u
+	if err := save(); err != nil {
+		users[old.Name] = old
+		return err
+	}
+	return nil
+}
+
+// SetPassword: 그 계정의 기존 세션은 다시 로그인해야 함
+func SetPassword(name, password string) error {
+	h, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	return update(name, func(u *User) error {
+		u.PasswordHash = h
+		u.DateCredentialsChanged = time.Now()
+		return nil
+	})
+}
+
+// SetRole: 권한이 바뀌면 그 계정의 기존 세션은 다시 로그인해야 함
+func SetRole(name string, role Role) error {
+	if _, err := ParseRole(string(role)); err != nil {
+		return err
+	}
+	return update(name, func(u *User) error {
+		if u.Role != role {
+			u.Role = Role(strings.Clone(string(role)))
+			u.DateCredentialsChanged = time.Now()
+		}
+		return nil
+	})
+}
+
+func Delete(name string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	old, has := users[name]
+	if !has {
+		return fmt.Errorf("name: %q 없는 계정입니다", name)
+	}
+	delete(users, name)
+	if err := save(); err != nil {
+		users[name] = old
+		return err
+	}
+	return nil
+}
+
+// Authenticate: 아이디, 비밀번호 확인. 실패하면 어느쪽이 틀렸는지 구분하지 않고 ErrInvalidLogin
+func Authenticate(name, password string) (*User, error) {
+	u, has := Get(name)
+	hash := dummyHash
+	if has {
+		hash = []byte(u.PasswordHash)
+	}
+	if err := bcrypt.CompareHashAndPassword(hash, []byte(password)); err != nil || !has {
+		return nil, ErrInvalidLogin
+	}
+	return u, nil
+}